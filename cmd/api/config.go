@@ -3,13 +3,17 @@ package main
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	"os"
 	"path"
 	"strings"
 
 	"github.com/omeid/uconfig"
 	"github.com/omeid/uconfig/plugins"
+	"github.com/omeid/uconfig/plugins/defaults"
+	"github.com/omeid/uconfig/plugins/env"
 	"github.com/omeid/uconfig/plugins/file"
+	uflag "github.com/omeid/uconfig/plugins/flag"
 	"github.com/rs/zerolog/log"
 	"github.com/textileio/go-tableland/internal/tableland"
 )
@@ -25,6 +29,7 @@ type config struct {
 	Gateway          GatewayConfig
 	TableConstraints TableConstraints
 	QueryConstraints QueryConstraints
+	UserStore        UserStoreConfig
 
 	Metrics struct {
 		Port string `default:"9090"`
@@ -46,11 +51,27 @@ type config struct {
 type HTTPConfig struct {
 	Port string `default:"8080"` // HTTP port (e.g. 8080)
 
-	TLSCert string `default:""`
-	TLSKey  string `default:""`
+	TLSCert string `default:""` // base64 encoded PEM certificate
+	TLSKey  string `default:""` // base64 encoded PEM key
+	// RedirectHTTPPort is the port of an optional HTTP server redirecting to HTTPS when TLS is enabled.
+	RedirectHTTPPort string `default:""`
 
 	RateLimInterval       string `default:"1s"`
 	MaxRequestPerInterval uint64 `default:"10"`
+	// RateLimTrustedProxies are the IPs or CIDRs of the load-balancers allowed to set X-Forwarded-For.
+	// If empty, the X-Forwarded-For header is ignored and the connection remote address is used.
+	RateLimTrustedProxies []string
+
+	// WebsocketAllowedOrigins are the origins accepted in /rpc/ws connections from browsers.
+	// If empty, only localhost is accepted. Use "*" to accept any origin.
+	WebsocketAllowedOrigins []string
+
+	// CORSAllowedOrigins are the origins allowed to make cross origin requests to the APIs.
+	// If empty, only same-origin requests are allowed. Use "*" to allow any origin.
+	CORSAllowedOrigins []string
+	// CORSAllowedMethods and CORSAllowedHeaders override the default allowed methods and headers.
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
 }
 
 // GatewayConfig contains configuration for the Gateway.
@@ -60,6 +81,14 @@ type GatewayConfig struct {
 	AnimationRendererURI string `default:""`
 }
 
+// UserStoreConfig contains configuration for the db connection pool used to serve read queries.
+type UserStoreConfig struct {
+	MaxOpenConns    int    `default:"0"` // 0 means no limit
+	MaxIdleConns    int    `default:"2"`
+	ConnMaxLifetime string `default:"0s"` // 0 means connections are reused forever
+	AcquireTimeout  string `default:"0s"` // 0 means reads wait for a connection until they time out
+}
+
 // BackupConfig contains configuration for automatic database backups.
 type BackupConfig struct {
 	Enabled           bool   `default:"true"`
@@ -86,12 +115,47 @@ type TelemetryPublisherConfig struct {
 // TableConstraints describes contraints to be enforced for Tableland tables.
 type TableConstraints struct {
 	MaxRowCount int `default:"100_000"`
+	// GateTableCreation indicates if only callers with the create privilege can relay table creations.
+	GateTableCreation bool `default:"false"`
+	// TableCreators are the addresses granted the create privilege on every chain at startup.
+	TableCreators []string
 }
 
 // QueryConstraints describes constraints to be enforced on queries.
 type QueryConstraints struct {
 	MaxWriteQuerySize int `default:"35000"`
 	MaxReadQuerySize  int `default:"35000"`
+	MaxReadRowCount   int `default:"0"` // 0 means no limit
+	// MaxReadQueryCost is the maximum estimated cost (roughly, scanned rows) of a read query.
+	MaxReadQueryCost int64 `default:"0"` // 0 means no limit
+	// AllowMixedOps allows write queries that mix INSERT, UPDATE and DELETE statements.
+	// It only applies to queries received by the API.
+	AllowMixedOps bool `default:"true"`
+	// RequireOrderByWithLimit rejects read queries that have a LIMIT without an ORDER BY.
+	RequireOrderByWithLimit bool `default:"false"`
+	// DisallowNullLiterals rejects write queries with explicit NULL values.
+	// It only applies to queries received by the API.
+	DisallowNullLiterals bool `default:"false"`
+	// RequireDeleteWhere rejects DELETE statements without a WHERE clause.
+	// It only applies to queries received by the API.
+	RequireDeleteWhere bool `default:"false"`
+	// RequireUpdateWhere rejects UPDATE statements without a WHERE clause.
+	// It only applies to queries received by the API.
+	RequireUpdateWhere bool `default:"false"`
+	// DeniedOperators are operators, such as regexp or glob, rejected in read queries and in the
+	// WHERE clause of write queries. It only applies to queries received by the API.
+	DeniedOperators []string
+	// EnabledTypes restricts the column types of created tables. If empty, all accepted types are
+	// enabled. It only applies to tables created through the API.
+	EnabledTypes []string
+	// CheckInsertColumnCount rejects INSERT statements with rows that don't match the column list.
+	// It only applies to queries received by the API.
+	CheckInsertColumnCount bool `default:"false"`
+	// CaseInsensitiveIdentifiers compares table names and system table prefixes ignoring casing.
+	// It only applies to queries received by the API.
+	CaseInsensitiveIdentifiers bool `default:"true"`
+	// ReceiptWaitTimeout is the maximum time a read waits for the receipt of a txn it depends on.
+	ReceiptWaitTimeout string `default:"10s"`
 }
 
 // ChainConfig contains all the chain execution stack configuration for a particular EVM chain.
@@ -100,8 +164,8 @@ type ChainConfig struct {
 	ChainID               tableland.ChainID `default:"0"`
 	AllowTransactionRelay bool              `default:"false"`
 	Registry              struct {
-		EthEndpoint     string `default:"eth_endpoint"`
-		ContractAddress string `default:"contract_address"`
+		EthEndpoint     string `default:""`
+		ContractAddress string `default:""`
 	}
 	Signer struct {
 		PrivateKey string `default:""`
@@ -122,6 +186,23 @@ type ChainConfig struct {
 		MinBlockDepth int    `default:"5"`
 	}
 	HashCalculationStep int64 `default:"1000"`
+	// TableConstraints overrides the global table constraints for this chain.
+	TableConstraints ChainTableConstraints
+}
+
+// ChainTableConstraints describes per-chain overrides of the table constraints.
+type ChainTableConstraints struct {
+	MaxRowCount int `default:"0"` // 0 means the global value is used
+}
+
+// resolveTableConstraints returns the table constraints that apply to the chain,
+// using the global constraints for the values the chain doesn't override.
+func (c ChainConfig) resolveTableConstraints(global TableConstraints) TableConstraints {
+	resolved := global
+	if c.TableConstraints.MaxRowCount > 0 {
+		resolved.MaxRowCount = c.TableConstraints.MaxRowCount
+	}
+	return resolved
 }
 
 func setupConfig() (*config, string) {
@@ -135,24 +216,71 @@ func setupConfig() (*config, string) {
 
 	_ = os.MkdirAll(dirPath, 0o755)
 
-	var plugins []plugins.Plugin
+	conf, err := loadConfig(dirPath, os.Args[1:])
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
+
+	return conf, dirPath
+}
+
+// loadConfig loads the configuration in layers, where each layer overrides the previous one:
+// defaults, the config file in dirPath (if present), environment variables, and finally flags.
+func loadConfig(dirPath string, args []string) (*config, error) {
+	ps := []plugins.Plugin{defaults.New()}
 	fullPath := path.Join(dirPath, configFilename)
 	configFileBytes, err := os.ReadFile(fullPath)
 	if os.IsNotExist(err) {
 		log.Info().Str("config_file_path", fullPath).Msg("config file not found")
 	} else if err != nil {
-		log.Fatal().Str("config_file_path", fullPath).Err(err).Msg("opening config file")
+		return nil, fmt.Errorf("opening config file %s: %s", fullPath, err)
 	} else {
 		fileStr := os.ExpandEnv(string(configFileBytes))
-		plugins = append(plugins, file.NewReader(strings.NewReader(fileStr), json.Unmarshal))
+		ps = append(ps, file.NewReader(strings.NewReader(fileStr), json.Unmarshal))
 	}
+	ps = append(ps, env.New(), uflag.New("api", uflag.ContinueOnError, args))
 
 	conf := &config{}
-	c, err := uconfig.Classic(&conf, file.Files{}, plugins...)
+	c, err := uconfig.New(conf, ps...)
 	if err != nil {
+		return nil, fmt.Errorf("creating config loader: %s", err)
+	}
+	if err := c.Parse(); err != nil {
 		c.Usage()
-		log.Fatal().Err(err).Msg("invalid configuration")
+		return nil, fmt.Errorf("parsing config: %s", err)
+	}
+	if err := conf.validate(); err != nil {
+		return nil, err
 	}
 
-	return conf, dirPath
+	return conf, nil
+}
+
+// validate checks that all the required configuration keys are set.
+func (c *config) validate() error {
+	var missing []string
+	if len(c.Chains) == 0 {
+		missing = append(missing, "Chains")
+	}
+	for i, chain := range c.Chains {
+		if chain.ChainID == 0 {
+			missing = append(missing, fmt.Sprintf("Chains[%d].ChainID", i))
+		}
+		if chain.Registry.EthEndpoint == "" {
+			missing = append(missing, fmt.Sprintf("Chains[%d].Registry.EthEndpoint", i))
+		}
+		if chain.Registry.ContractAddress == "" {
+			missing = append(missing, fmt.Sprintf("Chains[%d].Registry.ContractAddress", i))
+		}
+		if chain.Signer.PrivateKey == "" {
+			missing = append(missing, fmt.Sprintf("Chains[%d].Signer.PrivateKey", i))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required config keys: %s", strings.Join(missing, ", "))
+	}
+	if (c.HTTP.TLSCert == "") != (c.HTTP.TLSKey == "") {
+		return fmt.Errorf("HTTP.TLSCert and HTTP.TLSKey must be provided together")
+	}
+	return nil
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dirPath := t.TempDir()
+	configJSON := `{
+		"HTTP": {
+			"Port": "8081",
+			"RateLimInterval": "2s"
+		},
+		"Chains": [
+			{
+				"Name": "Local Hardhat",
+				"ChainID": 31337,
+				"Registry": {
+					"EthEndpoint": "ws://localhost:8545",
+					"ContractAddress": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+				},
+				"Signer": {
+					"PrivateKey": "${TEST_SIGNER_PRIVATE_KEY}"
+				}
+			}
+		]
+	}`
+	require.NoError(t, os.WriteFile(path.Join(dirPath, configFilename), []byte(configJSON), 0o644))
+
+	t.Setenv("TEST_SIGNER_PRIVATE_KEY", "deadbeef")
+	t.Setenv("HTTP_PORT", "9999")
+
+	conf, err := loadConfig(dirPath, nil)
+	require.NoError(t, err)
+
+	// Environment variables override the config file.
+	require.Equal(t, "9999", conf.HTTP.Port)
+	// Values from the config file override defaults.
+	require.Equal(t, "2s", conf.HTTP.RateLimInterval)
+	// Defaults are kept for missing keys.
+	require.Equal(t, uint64(10), conf.HTTP.MaxRequestPerInterval)
+
+	require.Len(t, conf.Chains, 1)
+	require.Equal(t, "deadbeef", conf.Chains[0].Signer.PrivateKey)
+	require.Equal(t, "ws://localhost:8545", conf.Chains[0].Registry.EthEndpoint)
+}
+
+func TestLoadConfigMissingKeys(t *testing.T) {
+	dirPath := t.TempDir()
+	configJSON := `{
+		"Chains": [
+			{
+				"ChainID": 31337,
+				"Registry": {
+					"EthEndpoint": "ws://localhost:8545"
+				}
+			}
+		]
+	}`
+	require.NoError(t, os.WriteFile(path.Join(dirPath, configFilename), []byte(configJSON), 0o644))
+
+	_, err := loadConfig(dirPath, nil)
+	require.EqualError(
+		t,
+		err,
+		"missing required config keys: Chains[0].Registry.ContractAddress, Chains[0].Signer.PrivateKey",
+	)
+
+	_, err = loadConfig(t.TempDir(), nil)
+	require.EqualError(t, err, "missing required config keys: Chains")
+}
+
+func TestValidateTLSConfig(t *testing.T) {
+	conf := &config{}
+	conf.Chains = []ChainConfig{{ChainID: 1}}
+	conf.Chains[0].Registry.EthEndpoint = "ws://localhost:8545"
+	conf.Chains[0].Registry.ContractAddress = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	conf.Chains[0].Signer.PrivateKey = "deadbeef"
+	require.NoError(t, conf.validate())
+
+	conf.HTTP.TLSCert = "cert"
+	require.EqualError(t, conf.validate(), "HTTP.TLSCert and HTTP.TLSKey must be provided together")
+
+	conf.HTTP.TLSKey = "key"
+	require.NoError(t, conf.validate())
+}
+
+func TestResolveTableConstraints(t *testing.T) {
+	dirPath := t.TempDir()
+	configJSON := `{
+		"TableConstraints": {
+			"MaxRowCount": 500
+		},
+		"Chains": [
+			{
+				"ChainID": 1,
+				"Registry": {
+					"EthEndpoint": "ws://localhost:8545",
+					"ContractAddress": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+				},
+				"Signer": {
+					"PrivateKey": "deadbeef"
+				},
+				"TableConstraints": {
+					"MaxRowCount": 10
+				}
+			},
+			{
+				"ChainID": 2,
+				"Registry": {
+					"EthEndpoint": "ws://localhost:8546",
+					"ContractAddress": "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+				},
+				"Signer": {
+					"PrivateKey": "deadbeef"
+				}
+			}
+		]
+	}`
+	require.NoError(t, os.WriteFile(path.Join(dirPath, configFilename), []byte(configJSON), 0o644))
+
+	conf, err := loadConfig(dirPath, nil)
+	require.NoError(t, err)
+	require.Len(t, conf.Chains, 2)
+
+	require.Equal(t, 10, conf.Chains[0].resolveTableConstraints(conf.TableConstraints).MaxRowCount)
+	require.Equal(t, 500, conf.Chains[1].resolveTableConstraints(conf.TableConstraints).MaxRowCount)
+}
@@ -6,7 +6,9 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"path"
 	"sync"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/textileio/go-tableland/buildinfo"
 	"github.com/textileio/go-tableland/internal/chains"
 	"github.com/textileio/go-tableland/internal/router"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
 	systemimpl "github.com/textileio/go-tableland/internal/system/impl"
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/internal/tableland/impl"
@@ -77,11 +80,16 @@ func main() {
 		}
 	}
 
-	// Parser.
+	// Parsers. The gateway parser also enforces the constraints that only apply to queries received
+	// by the API, since executors must accept any query that's valid on-chain.
 	parser, err := createParser(config.QueryConstraints)
 	if err != nil {
 		log.Fatal().Err(err).Msg("creating parser")
 	}
+	gatewayParser, err := createGatewayParser(config.QueryConstraints)
+	if err != nil {
+		log.Fatal().Err(err).Msg("creating gateway parser")
+	}
 
 	// Chain stacks.
 	chainStacks, closeChainStacks, err := createChainStacks(
@@ -99,13 +107,38 @@ func main() {
 	for chainID, stack := range chainStacks {
 		eps[chainID] = stack.EventProcessor
 	}
-	userStore, err := user.New(databaseURL, readstatementresolver.New(eps))
+	connMaxLifetime, err := time.ParseDuration(config.UserStore.ConnMaxLifetime)
+	if err != nil {
+		log.Fatal().Err(err).Msg("parsing user store connection max lifetime")
+	}
+	acquireTimeout, err := time.ParseDuration(config.UserStore.AcquireTimeout)
+	if err != nil {
+		log.Fatal().Err(err).Msg("parsing user store acquire timeout")
+	}
+	userStore, err := user.New(
+		databaseURL,
+		readstatementresolver.New(eps),
+		config.QueryConstraints.MaxReadRowCount,
+		user.WithMaxQueryCost(config.QueryConstraints.MaxReadQueryCost),
+		user.WithMaxOpenConns(config.UserStore.MaxOpenConns),
+		user.WithMaxIdleConns(config.UserStore.MaxIdleConns),
+		user.WithConnMaxLifetime(connMaxLifetime),
+		user.WithAcquireTimeout(acquireTimeout),
+	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("creating user store")
 	}
 
 	// HTTP API server.
-	closeHTTPServer, err := createAPIServer(config.HTTP, config.Gateway, parser, userStore, chainStacks)
+	closeHTTPServer, err := createAPIServer(
+		config.HTTP,
+		config.Gateway,
+		config.TableConstraints,
+		config.QueryConstraints,
+		databaseURL,
+		gatewayParser,
+		userStore,
+		chainStacks)
 	if err != nil {
 		log.Fatal().Err(err).Msg("creating HTTP server")
 	}
@@ -177,6 +210,15 @@ func createChainIDStack(
 		return chains.ChainStack{}, fmt.Errorf("instrumenting system store: %s", err)
 	}
 
+	for _, creator := range tableConstraints.TableCreators {
+		if !common.IsHexAddress(creator) {
+			return chains.ChainStack{}, fmt.Errorf("table creator %s isn't an address", creator)
+		}
+		if err := systemStore.GrantCreatePrivilege(context.Background(), common.HexToAddress(creator)); err != nil {
+			return chains.ChainStack{}, fmt.Errorf("granting create privilege: %s", err)
+		}
+	}
+
 	conn, err := ethclient.Dial(config.Registry.EthEndpoint)
 	if err != nil {
 		return chains.ChainStack{}, fmt.Errorf("failed to connect to ethereum endpoint: %s", err)
@@ -229,6 +271,7 @@ func createChainIDStack(
 
 	acl := impl.NewACL(systemStore, registry)
 
+	tableConstraints = config.resolveTableConstraints(tableConstraints)
 	ex, err := executor.NewExecutor(config.ChainID, executorsDB, parser, tableConstraints.MaxRowCount, acl)
 	if err != nil {
 		return chains.ChainStack{}, fmt.Errorf("creating txn processor: %s", err)
@@ -273,6 +316,7 @@ func createChainIDStack(
 		Registry:              registry,
 		EventProcessor:        ep,
 		AllowTransactionRelay: config.AllowTransactionRelay,
+		MaxTableRowCount:      tableConstraints.MaxRowCount,
 		Close: func(ctx context.Context) error {
 			log.Info().Int64("chain_id", int64(config.ChainID)).Msg("closing stack...")
 			defer log.Info().Int64("chain_id", int64(config.ChainID)).Msg("stack closed")
@@ -388,16 +432,7 @@ func restoreBackup(databaseURL string, backupURL string) error {
 }
 
 func createParser(queryConstraints QueryConstraints) (parsing.SQLValidator, error) {
-	parserOpts := []parsing.Option{
-		parsing.WithMaxReadQuerySize(queryConstraints.MaxReadQuerySize),
-		parsing.WithMaxWriteQuerySize(queryConstraints.MaxWriteQuerySize),
-	}
-
-	parser, err := parserimpl.New([]string{
-		"sqlite_",
-		systemimpl.SystemTablesPrefix,
-		systemimpl.RegistryTableName,
-	}, parserOpts...)
+	parser, err := parserimpl.New(systemTablePrefixes(), parserOptions(queryConstraints)...)
 	if err != nil {
 		return nil, fmt.Errorf("new parser: %s", err)
 	}
@@ -410,6 +445,47 @@ func createParser(queryConstraints QueryConstraints) (parsing.SQLValidator, erro
 	return parser, nil
 }
 
+func createGatewayParser(queryConstraints QueryConstraints) (parsing.SQLValidator, error) {
+	parser, err := parserimpl.NewGateway(
+		systemTablePrefixes(),
+		parserOptions(queryConstraints),
+		parsing.WithAllowMixedOps(queryConstraints.AllowMixedOps),
+		parsing.WithDisallowNullLiterals(queryConstraints.DisallowNullLiterals),
+		parsing.WithRequireDeleteWhere(queryConstraints.RequireDeleteWhere),
+		parsing.WithRequireUpdateWhere(queryConstraints.RequireUpdateWhere),
+		parsing.WithDeniedOperators(queryConstraints.DeniedOperators...),
+		parsing.WithEnabledTypes(queryConstraints.EnabledTypes...),
+		parsing.WithInsertColumnCountCheck(queryConstraints.CheckInsertColumnCount),
+		parsing.WithCaseInsensitiveIdentifiers(queryConstraints.CaseInsensitiveIdentifiers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("new gateway parser: %s", err)
+	}
+
+	parser, err = parserimpl.NewInstrumentedSQLValidator(parser)
+	if err != nil {
+		return nil, fmt.Errorf("instrumenting gateway parser: %s", err)
+	}
+
+	return parser, nil
+}
+
+func parserOptions(queryConstraints QueryConstraints) []parsing.Option {
+	return []parsing.Option{
+		parsing.WithMaxReadQuerySize(queryConstraints.MaxReadQuerySize),
+		parsing.WithMaxWriteQuerySize(queryConstraints.MaxWriteQuerySize),
+		parsing.WithRequireOrderByWithLimit(queryConstraints.RequireOrderByWithLimit),
+	}
+}
+
+func systemTablePrefixes() []string {
+	return []string{
+		"sqlite_",
+		systemimpl.SystemTablesPrefix,
+		systemimpl.RegistryTableName,
+	}
+}
+
 func createChainStacks(
 	databaseURL string,
 	parser parsing.SQLValidator,
@@ -477,6 +553,9 @@ func createChainStacks(
 func createAPIServer(
 	httpConfig HTTPConfig,
 	gatewayConfig GatewayConfig,
+	tableConstraints TableConstraints,
+	queryConstraints QueryConstraints,
+	databaseURL string,
 	parser parsing.SQLValidator,
 	userStore *user.UserStore,
 	chainStacks map[tableland.ChainID]chains.ChainStack,
@@ -486,7 +565,19 @@ func createAPIServer(
 		return nil, fmt.Errorf("creating instrumented user store: %s", err)
 	}
 
-	mesaService := impl.NewTablelandMesa(parser, instrUserStore, chainStacks)
+	receiptWaitTimeout, err := time.ParseDuration(queryConstraints.ReceiptWaitTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("parsing receipt wait timeout: %s", err)
+	}
+	mesaService, err := impl.NewTablelandMesa(
+		parser,
+		instrUserStore,
+		chainStacks,
+		impl.WithReceiptWaitTimeout(receiptWaitTimeout),
+		impl.WithTableCreationGating(tableConstraints.GateTableCreation))
+	if err != nil {
+		return nil, fmt.Errorf("creating mesa: %s", err)
+	}
 	mesaService, err = impl.NewInstrumentedTablelandMesa(mesaService)
 	if err != nil {
 		return nil, fmt.Errorf("instrumenting mesa: %s", err)
@@ -515,12 +606,27 @@ func createAPIServer(
 		return nil, fmt.Errorf("parsing http ratelimiter interval: %s", err)
 	}
 
+	readiness, err := system.NewReadinessChecker(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("creating readiness checker: %s", err)
+	}
+
 	router, err := router.ConfiguredRouter(
 		mesaService,
+		"mesa",
 		systemService,
+		userStore,
+		readiness,
 		httpConfig.MaxRequestPerInterval,
 		rateLimInterval,
+		httpConfig.RateLimTrustedProxies,
 		supportedChainIDs,
+		httpConfig.WebsocketAllowedOrigins,
+		middlewares.CORSConfig{
+			AllowedOrigins: httpConfig.CORSAllowedOrigins,
+			AllowedMethods: httpConfig.CORSAllowedMethods,
+			AllowedHeaders: httpConfig.CORSAllowedHeaders,
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("configuring router: %s", err)
@@ -535,30 +641,23 @@ func createAPIServer(
 		Handler:      router.Handler(),
 	}
 
+	var redirectServer *http.Server
 	if httpConfig.TLSCert != "" {
-		tlsCert, err := base64.StdEncoding.DecodeString(httpConfig.TLSCert)
+		tlsConfig, err := createTLSConfig(httpConfig.TLSCert, httpConfig.TLSKey)
 		if err != nil {
-			return nil, fmt.Errorf("base64 decoding TLS certificate: %s", err)
-		}
-		tlsKey, err := base64.StdEncoding.DecodeString(httpConfig.TLSKey)
-		if err != nil {
-			return nil, fmt.Errorf("base64 decoding TLS key: %s", err)
+			return nil, fmt.Errorf("creating TLS config: %s", err)
 		}
+		server.TLSConfig = tlsConfig
+		server.Addr = ":443"
 
-		cert, err := tls.X509KeyPair(tlsCert, tlsKey)
-		if err != nil {
-			return nil, fmt.Errorf("parsing TLS certificate: %s", err)
-		}
-		server.TLSConfig = &tls.Config{
-			Certificates: []tls.Certificate{cert},
-			MinVersion:   tls.VersionTLS13,
-			CipherSuites: []uint16{
-				tls.TLS_AES_128_GCM_SHA256,
-				tls.TLS_AES_256_GCM_SHA384,
-				tls.TLS_CHACHA20_POLY1305_SHA256,
-			},
+		if httpConfig.RedirectHTTPPort != "" {
+			redirectServer = &http.Server{
+				Addr:         ":" + httpConfig.RedirectHTTPPort,
+				ReadTimeout:  10 * time.Second,
+				WriteTimeout: 10 * time.Second,
+				Handler:      redirectToHTTPS(),
+			}
 		}
-		server.Addr = ":443"
 	}
 
 	go func() {
@@ -581,16 +680,74 @@ func createAPIServer(
 		}
 	}()
 
+	if redirectServer != nil {
+		go func() {
+			if err := redirectServer.ListenAndServe(); err != nil {
+				if err == http.ErrServerClosed {
+					log.Info().Msg("http redirect server gracefully closed")
+					return
+				}
+				log.Fatal().Err(err).Str("port", httpConfig.RedirectHTTPPort).Msg("couldn't start HTTP redirect server")
+			}
+		}()
+	}
+
 	closeModule := func(ctx context.Context) error {
+		if redirectServer != nil {
+			if err := redirectServer.Shutdown(ctx); err != nil {
+				return fmt.Errorf("closing HTTP redirect server")
+			}
+		}
 		if err := server.Shutdown(ctx); err != nil {
 			return fmt.Errorf("closing HTTP server")
 		}
+		if err := readiness.Close(); err != nil {
+			return fmt.Errorf("closing readiness checker: %s", err)
+		}
 		return nil
 	}
 
 	return closeModule, nil
 }
 
+// createTLSConfig builds the TLS configuration from a base64 encoded PEM certificate and key.
+func createTLSConfig(b64Cert, b64Key string) (*tls.Config, error) {
+	tlsCert, err := base64.StdEncoding.DecodeString(b64Cert)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decoding TLS certificate: %s", err)
+	}
+	tlsKey, err := base64.StdEncoding.DecodeString(b64Key)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decoding TLS key: %s", err)
+	}
+
+	cert, err := tls.X509KeyPair(tlsCert, tlsKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing TLS certificate: %s", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		CipherSuites: []uint16{
+			tls.TLS_AES_128_GCM_SHA256,
+			tls.TLS_AES_256_GCM_SHA384,
+			tls.TLS_CHACHA20_POLY1305_SHA256,
+		},
+	}, nil
+}
+
+// redirectToHTTPS returns a handler that permanently redirects requests to the same URL using HTTPS.
+func redirectToHTTPS() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		target := url.URL{Scheme: "https", Host: host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+		http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+	})
+}
+
 func createBackuper(dirPath string, config BackupConfig) (moduleCloser, error) {
 	backupScheduler, err := backup.NewScheduler(config.Frequency, backup.BackuperOptions{
 		SourcePath: path.Join(dirPath, "database.db"),
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSServer(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := selfSignedCert(t)
+	tlsConfig, err := createTLSConfig(
+		base64.StdEncoding.EncodeToString(certPEM),
+		base64.StdEncoding.EncodeToString(keyPEM),
+	)
+	require.NoError(t, err)
+
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("test", &echoService{}))
+
+	server := httptest.NewUnstartedServer(rpcServer)
+	server.TLS = tlsConfig
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	certPool := x509.NewCertPool()
+	require.True(t, certPool.AppendCertsFromPEM(certPEM))
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS13},
+		},
+	}
+	client, err := rpc.DialHTTPWithClient(server.URL, httpClient)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	var res string
+	require.NoError(t, client.CallContext(context.Background(), &res, "test_echo", "hello"))
+	require.Equal(t, "hello", res)
+}
+
+func TestTLSConfigInvalidCert(t *testing.T) {
+	t.Parallel()
+
+	_, err := createTLSConfig("not base64", "")
+	require.Error(t, err)
+
+	_, err = createTLSConfig(
+		base64.StdEncoding.EncodeToString([]byte("cert")),
+		base64.StdEncoding.EncodeToString([]byte("key")),
+	)
+	require.Error(t, err)
+}
+
+func TestRedirectToHTTPS(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "http://validator.example.com:8080/rpc?foo=bar", nil)
+	rr := httptest.NewRecorder()
+	redirectToHTTPS().ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusPermanentRedirect, rr.Code)
+	require.Equal(t, "https://validator.example.com/rpc?foo=bar", rr.Header().Get("Location"))
+}
+
+type echoService struct{}
+
+func (s *echoService) Echo(msg string) string {
+	return msg
+}
+
+func selfSignedCert(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"Tableland"}},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
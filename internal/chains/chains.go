@@ -14,6 +14,8 @@ type ChainStack struct {
 	Registry              tables.TablelandTables
 	EventProcessor        eventprocessor.EventProcessor
 	AllowTransactionRelay bool
+	// MaxTableRowCount is the maximum number of rows a table can have, or 0 if there's no limit.
+	MaxTableRowCount int
 
 	// close gracefully closes all the chain stack components.
 	Close func(ctx context.Context) error
@@ -2,6 +2,8 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 
@@ -16,11 +18,15 @@ const (
 	Table Output = "table"
 	// Objects returns the query results as a JSON array of JSON objects. This is the default.
 	Objects Output = "objects"
+	// CSV returns the query results as RFC 4180 CSV with a header row containing the column names.
+	// NULL values are returned as empty fields. Unwrap and extract options are ignored.
+	CSV Output = "csv"
 )
 
 var outputsMap = map[string]Output{
 	"table":   Table,
 	"objects": Objects,
+	"csv":     CSV,
 }
 
 // OutputFromString converts a string into an Output.
@@ -31,9 +37,11 @@ func OutputFromString(o string) (Output, bool) {
 
 // FormatConfig is the format configuration used.
 type FormatConfig struct {
-	Output  Output
-	Unwrap  bool
-	Extract bool
+	Output           Output
+	Unwrap           bool
+	Extract          bool
+	Object           bool
+	RequireSingleRow bool
 }
 
 // FormatOption controls the behavior of calls to Format.
@@ -63,6 +71,35 @@ func WithExtract(extract bool) FormatOption {
 	}
 }
 
+// WithObject specifies whether or not to return a single-row result as a bare JSON object
+// instead of an array with one JSON object. Results with zero or multiple rows are still returned
+// as an array, unless WithRequireSingleRow is set. It only applies to the Objects output.
+// Default is false.
+func WithObject(object bool) FormatOption {
+	return func(fc *FormatConfig) {
+		fc.Object = object
+	}
+}
+
+// WithRequireSingleRow specifies whether or not to fail with an *ErrNotSingleRow error when
+// formatting in object mode a result that doesn't have exactly one row.
+// Default is false.
+func WithRequireSingleRow(require bool) FormatOption {
+	return func(fc *FormatConfig) {
+		fc.RequireSingleRow = require
+	}
+}
+
+// ErrNotSingleRow is returned when a single row is required in object mode
+// but the result has a different number of rows.
+type ErrNotSingleRow struct {
+	Rows int
+}
+
+func (e *ErrNotSingleRow) Error() string {
+	return fmt.Sprintf("expected a single row but the result has %d", e.Rows)
+}
+
 // Format transforms the user rows according to the provided configuration, retuning raw json or jsonl bytes.
 func Format(userRows *tableland.TableData, opts ...FormatOption) ([]byte, FormatConfig, error) {
 	c := FormatConfig{
@@ -80,6 +117,14 @@ func Format(userRows *tableland.TableData, opts ...FormatOption) ([]byte, Format
 		return b, c, nil
 	}
 
+	if c.Output == CSV {
+		b, err := toCSV(userRows)
+		if err != nil {
+			return nil, FormatConfig{}, fmt.Errorf("writing csv: %s", err)
+		}
+		return b, c, nil
+	}
+
 	objects := toObjects(userRows)
 	var err error
 
@@ -90,6 +135,19 @@ func Format(userRows *tableland.TableData, opts ...FormatOption) ([]byte, Format
 		}
 	}
 
+	if c.Object {
+		if len(objects) == 1 {
+			b, err := json.Marshal(objects[0])
+			if err != nil {
+				return nil, FormatConfig{}, fmt.Errorf("marshaling to json: %v", err)
+			}
+			return b, c, nil
+		}
+		if c.RequireSingleRow {
+			return nil, FormatConfig{}, &ErrNotSingleRow{Rows: len(objects)}
+		}
+	}
+
 	if !c.Unwrap {
 		b, err := json.Marshal(objects)
 		if err != nil {
@@ -117,6 +175,49 @@ func toObjects(in *tableland.TableData) []interface{} {
 	return objects
 }
 
+func toCSV(in *tableland.TableData) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	w := csv.NewWriter(buf)
+
+	record := make([]string, len(in.Columns))
+	for i, col := range in.Columns {
+		record[i] = col.Name
+	}
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+	for _, row := range in.Rows {
+		for i, val := range row {
+			record[i] = csvField(val)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func csvField(cv *tableland.ColumnValue) string {
+	if cv == nil {
+		return ""
+	}
+	switch v := cv.Value().(type) {
+	case nil:
+		return ""
+	case json.RawMessage:
+		return string(v)
+	case []byte:
+		// Keep the same representation used for BLOBs in JSON outputs.
+		return base64.StdEncoding.EncodeToString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 func extract(in []interface{}) ([]interface{}, error) {
 	extracted := make([]interface{}, len(in))
 	for i, item := range in {
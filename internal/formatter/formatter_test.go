@@ -22,6 +22,24 @@ var input = &tableland.TableData{
 	},
 }
 
+var inputSingleRow = &tableland.TableData{
+	Columns: []tableland.Column{
+		{Name: "name"},
+		{Name: "age"},
+	},
+	Rows: [][]*tableland.ColumnValue{
+		{tableland.OtherColValue("bob"), tableland.OtherColValue(40)},
+	},
+}
+
+var inputNoRows = &tableland.TableData{
+	Columns: []tableland.Column{
+		{Name: "name"},
+		{Name: "age"},
+	},
+	Rows: [][]*tableland.ColumnValue{},
+}
+
 var inputExtractable = &tableland.TableData{
 	Columns: []tableland.Column{
 		{Name: "name"},
@@ -44,10 +62,12 @@ var inputExtractable2 = &tableland.TableData{
 
 func TestFormat(t *testing.T) {
 	type args struct {
-		userRows *tableland.TableData
-		output   Output
-		unwrap   bool
-		extract  bool
+		userRows         *tableland.TableData
+		output           Output
+		unwrap           bool
+		extract          bool
+		object           bool
+		requireSingleRow bool
 	}
 	tests := []struct {
 		name    string
@@ -85,6 +105,11 @@ func TestFormat(t *testing.T) {
 			args: args{userRows: input, output: Objects, unwrap: true},
 			want: "{\"name\":\"bob\",\"age\":40,\"location\":{\"city\":\"dallas\"}}\n{\"name\":\"jane\",\"age\":30,\"location\":{\"city\":\"dallas\"}}\n", // nolint
 		},
+		{
+			name: "objects, unwrap single row",
+			args: args{userRows: inputSingleRow, output: Objects, unwrap: true},
+			want: "{\"name\":\"bob\",\"age\":40}",
+		},
 		{
 			name: "objects, extract, unwrap",
 			args: args{userRows: inputExtractable, output: Objects, extract: true, unwrap: true},
@@ -95,6 +120,41 @@ func TestFormat(t *testing.T) {
 			args: args{userRows: inputExtractable2, output: Objects, extract: true, unwrap: true},
 			want: "{\"city\":\"dallas\"}\n{\"city\":\"dallas\"}",
 		},
+		{
+			name: "objects, object single row",
+			args: args{userRows: inputSingleRow, output: Objects, object: true},
+			want: "{\"name\":\"bob\",\"age\":40}",
+		},
+		{
+			name: "objects, object no rows",
+			args: args{userRows: inputNoRows, output: Objects, object: true},
+			want: "[]",
+		},
+		{
+			name: "objects, object multiple rows",
+			args: args{userRows: input, output: Objects, object: true},
+			want: "[{\"name\":\"bob\",\"age\":40,\"location\":{\"city\":\"dallas\"}},{\"name\":\"jane\",\"age\":30,\"location\":{\"city\":\"dallas\"}}]", // nolint
+		},
+		{
+			name: "objects, object single row, require single row",
+			args: args{userRows: inputSingleRow, output: Objects, object: true, requireSingleRow: true},
+			want: "{\"name\":\"bob\",\"age\":40}",
+		},
+		{
+			name:    "objects, object no rows, require single row",
+			args:    args{userRows: inputNoRows, output: Objects, object: true, requireSingleRow: true},
+			wantErr: true,
+		},
+		{
+			name:    "objects, object multiple rows, require single row",
+			args:    args{userRows: input, output: Objects, object: true, requireSingleRow: true},
+			wantErr: true,
+		},
+		{
+			name: "objects, require single row without object",
+			args: args{userRows: input, output: Objects, requireSingleRow: true},
+			want: "[{\"name\":\"bob\",\"age\":40,\"location\":{\"city\":\"dallas\"}},{\"name\":\"jane\",\"age\":30,\"location\":{\"city\":\"dallas\"}}]", // nolint
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -103,6 +163,8 @@ func TestFormat(t *testing.T) {
 				WithOutput(tt.args.output),
 				WithUnwrap(tt.args.unwrap),
 				WithExtract(tt.args.extract),
+				WithObject(tt.args.object),
+				WithRequireSingleRow(tt.args.requireSingleRow),
 			)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Format() error = %v, wantErr %v", err, tt.wantErr)
@@ -126,6 +188,52 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestFormatRequireSingleRow(t *testing.T) {
+	_, _, err := Format(input, WithObject(true), WithRequireSingleRow(true))
+	var errNotSingleRow *ErrNotSingleRow
+	require.ErrorAs(t, err, &errNotSingleRow)
+	require.Equal(t, 2, errNotSingleRow.Rows)
+
+	_, _, err = Format(inputNoRows, WithObject(true), WithRequireSingleRow(true))
+	require.ErrorAs(t, err, &errNotSingleRow)
+	require.Equal(t, 0, errNotSingleRow.Rows)
+}
+
+func TestFormatCSV(t *testing.T) {
+	t.Parallel()
+
+	in := &tableland.TableData{
+		Columns: []tableland.Column{
+			{Name: "name"},
+			{Name: "age"},
+			{Name: "address"},
+			{Name: "location"},
+		},
+		Rows: [][]*tableland.ColumnValue{
+			{
+				tableland.OtherColValue("bob"),
+				tableland.OtherColValue(40),
+				tableland.OtherColValue("1 Main St, Dallas"),
+				tableland.JSONColValue(rawJSON),
+			},
+			{
+				tableland.OtherColValue("jane"),
+				tableland.OtherColValue(nil),
+				tableland.OtherColValue("line 1\nline 2"),
+				tableland.OtherColValue([]byte("AAA")),
+			},
+		},
+	}
+
+	got, config, err := Format(in, WithOutput(CSV))
+	require.NoError(t, err)
+	require.Equal(t, CSV, config.Output)
+	want := "name,age,address,location\n" +
+		"bob,40,\"1 Main St, Dallas\",\"{\"\"city\"\":\"\"dallas\"\"}\"\n" +
+		"jane,,\"line 1\nline 2\",QUFB\n"
+	require.Equal(t, want, string(got))
+}
+
 func parseJSONLString(val string) []string {
 	s := strings.TrimRight(val, "\n")
 	return strings.Split(s, "\n")
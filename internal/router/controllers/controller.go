@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -205,13 +206,15 @@ func (c *Controller) GetTableRow(rw http.ResponseWriter, r *http.Request) {
 		}
 		formatted, config, err := formatter.Format(res, opts...)
 		if err != nil {
-			rw.WriteHeader(http.StatusInternalServerError)
+			rw.WriteHeader(formatErrorStatus(err))
 			msg := fmt.Sprintf("Error formatting data: %v", err)
 			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
 			log.Ctx(r.Context()).Error().Err(err).Msg(msg)
 			return
 		}
-		if config.Unwrap && len(res.Rows) > 1 {
+		if config.Output == formatter.CSV {
+			rw.Header().Set("Content-Type", "text/csv")
+		} else if config.Unwrap && len(res.Rows) > 1 {
 			rw.Header().Set("Content-Type", "application/jsonl+json")
 		}
 		rw.WriteHeader(http.StatusOK)
@@ -503,9 +506,53 @@ func (c *Controller) GetSchemaByTableName(rw http.ResponseWriter, r *http.Reques
 	})
 }
 
-// HealthHandler serves health check requests.
-func HealthHandler(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
+// Pinger checks the connection with the database.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+const healthCheckTimeout = 2 * time.Second
+
+// NewHealthHandler returns a handler that serves health check requests.
+// It responds with 200 if the database answers a ping, and 503 otherwise.
+// The body reports the Tableland implementation being served (e.g: mesa or mock).
+func NewHealthHandler(db Pinger, tablelandImpl string) http.HandlerFunc {
+	type response struct {
+		Impl string `json:"impl"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+		if err := db.PingContext(ctx); err != nil {
+			log.Ctx(r.Context()).Error().Err(err).Msg("health check database ping")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(response{Impl: tablelandImpl})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response{Impl: tablelandImpl})
+	}
+}
+
+// ReadinessChecker checks if the database is ready to serve requests.
+type ReadinessChecker interface {
+	CheckReadiness(ctx context.Context) error
+}
+
+// NewReadyHandler returns a handler that serves readiness check requests.
+// It responds with 200 if the system tables are migrated to the expected version, and 503 otherwise.
+func NewReadyHandler(checker ReadinessChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+		if err := checker.CheckReadiness(ctx); err != nil {
+			log.Ctx(r.Context()).Warn().Err(err).Msg("readiness check")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
 }
 
 // GetTableQuery handles the GET /query?s=[statement] call.
@@ -535,7 +582,7 @@ func (c *Controller) GetTableQuery(rw http.ResponseWriter, r *http.Request) {
 	}
 	formatted, config, err := formatter.Format(res, opts...)
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
+		rw.WriteHeader(formatErrorStatus(err))
 		msg := fmt.Sprintf("Error formatting data: %v", err)
 		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
 		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
@@ -544,10 +591,12 @@ func (c *Controller) GetTableQuery(rw http.ResponseWriter, r *http.Request) {
 
 	CollectReadQueryMetric(r.Context(), stm, config, took)
 
-	rw.WriteHeader(http.StatusOK)
-	if config.Unwrap && len(res.Rows) > 1 {
+	if config.Output == formatter.CSV {
+		rw.Header().Set("Content-Type", "text/csv")
+	} else if config.Unwrap && len(res.Rows) > 1 {
 		rw.Header().Set("Content-Type", "application/jsonl+json")
 	}
+	rw.WriteHeader(http.StatusOK)
 	_, _ = rw.Write(formatted)
 }
 
@@ -591,13 +640,31 @@ func formatterOptions(r *http.Request) ([]formatter.FormatOption, error) {
 	if params.unwrap != nil {
 		opts = append(opts, formatter.WithUnwrap(*params.unwrap))
 	}
+	if params.object != nil {
+		opts = append(opts, formatter.WithObject(*params.object))
+	}
+	if params.strict != nil {
+		opts = append(opts, formatter.WithRequireSingleRow(*params.strict))
+	}
 	return opts, nil
 }
 
+// formatErrorStatus returns the HTTP status for an error formatting read results. Requiring a
+// single row is a client decision, so it isn't reported as a server error.
+func formatErrorStatus(err error) int {
+	var errNotSingleRow *formatter.ErrNotSingleRow
+	if stderrors.As(err, &errNotSingleRow) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
 type formatterParams struct {
 	output  *formatter.Output
 	extract *bool
 	unwrap  *bool
+	object  *bool
+	strict  *bool
 }
 
 func getFormatterParams(r *http.Request) (formatterParams, error) {
@@ -609,6 +676,8 @@ func getFormatterParams(r *http.Request) (formatterParams, error) {
 
 	extract := r.URL.Query().Get("extract")
 	unwrap := r.URL.Query().Get("unwrap")
+	object := r.URL.Query().Get("object")
+	strict := r.URL.Query().Get("strict")
 	if output != "" {
 		output, ok := formatter.OutputFromString(output)
 		if !ok {
@@ -630,6 +699,20 @@ func getFormatterParams(r *http.Request) (formatterParams, error) {
 		}
 		c.unwrap = &unwrap
 	}
+	if object != "" {
+		object, err := strconv.ParseBool(object)
+		if err != nil {
+			return formatterParams{}, err
+		}
+		c.object = &object
+	}
+	if strict != "" {
+		strict, err := strconv.ParseBool(strict)
+		if err != nil {
+			return formatterParams{}, err
+		}
+		c.strict = &strict
+	}
 
 	// Special handling for old mode param
 	mode := r.URL.Query().Get("mode")
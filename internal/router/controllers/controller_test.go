@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,11 +11,13 @@ import (
 	"testing"
 
 	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	systemimpl "github.com/textileio/go-tableland/internal/system/impl"
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/mocks"
+	"github.com/textileio/go-tableland/tests"
 )
 
 func TestGetTableRow(t *testing.T) {
@@ -186,6 +190,22 @@ func TestQuery(t *testing.T) {
 	for i, wantString := range wantStrings {
 		require.JSONEq(t, wantString, gotStrings[i])
 	}
+
+	// Object mode with multiple rows falls back to an array
+	req, err = http.NewRequest("GET", "/query?s=select%20*%20from%20foo%3B&output=objects&object=true", nil)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	exp = `[{"eyes":"Big","id":1,"mouth":"Surprised"},{"eyes":"Medium","id":2,"mouth":"Sad"},{"eyes":"Small","id":3,"mouth":"Happy"}]` // nolint
+	require.JSONEq(t, exp, rr.Body.String())
+
+	// Object mode requiring a single row
+	req, err = http.NewRequest("GET", "/query?s=select%20*%20from%20foo%3B&output=objects&object=true&strict=true", nil)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
 func TestLegacyQuery(t *testing.T) {
@@ -494,3 +514,54 @@ func parseJSONLString(val string) []string {
 	s := strings.TrimRight(val, "\n")
 	return strings.Split(s, "\n")
 }
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", tests.Sqlite3URI(t))
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/health", NewHealthHandler(db, "mock"))
+
+	req, err := http.NewRequest("GET", "/health", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"impl":"mock"}`, rr.Body.String())
+
+	require.NoError(t, db.Close())
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	require.JSONEq(t, `{"impl":"mock"}`, rr.Body.String())
+}
+
+type readinessCheckerMock struct {
+	err error
+}
+
+func (c *readinessCheckerMock) CheckReadiness(context.Context) error {
+	return c.err
+}
+
+func TestReadyHandler(t *testing.T) {
+	t.Parallel()
+
+	checker := &readinessCheckerMock{err: errors.New("table system_acl doesn't exist")}
+	router := mux.NewRouter()
+	router.HandleFunc("/ready", NewReadyHandler(checker))
+
+	req, err := http.NewRequest("GET", "/ready", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	checker.err = nil
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+}
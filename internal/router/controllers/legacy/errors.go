@@ -0,0 +1,128 @@
+package legacy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tablelandnetwork/sqlparser"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/sqlstore"
+)
+
+// JSON-RPC error codes returned for known errors. Any other error is
+// returned with the go-ethereum default code (-32000).
+const (
+	// ErrCodeInvalidStatement indicates that the statement can't be parsed, e.g: it has
+	// a syntax error or uses a column type that isn't supported.
+	ErrCodeInvalidStatement = -32001
+	// ErrCodeStatementNotAllowed indicates that the statement is valid SQL but isn't
+	// allowed by Tableland, e.g: it references a system table.
+	ErrCodeStatementNotAllowed = -32002
+	// ErrCodeQueryTooLong indicates that the query exceeds the maximum allowed size.
+	ErrCodeQueryTooLong = -32003
+	// ErrCodeUnsupportedChain indicates that the chain isn't supported by the validator.
+	ErrCodeUnsupportedChain = -32004
+	// ErrCodeTooManyRows indicates that a read query result exceeds the maximum number of rows.
+	ErrCodeTooManyRows = -32005
+	// ErrCodeReadTimeout indicates that a read query took longer than allowed.
+	ErrCodeReadTimeout = -32006
+	// ErrCodeQueryTooCostly indicates that the estimated cost of a read query exceeds the maximum allowed.
+	ErrCodeQueryTooCostly = -32007
+	// ErrCodePoolExhausted indicates that the validator is too busy to serve a read query. Clients should back off.
+	ErrCodePoolExhausted = -32008
+)
+
+// codedError is an error with a JSON-RPC error code. It implements the rpc.Error
+// interface of go-ethereum, so the code is sent to the client.
+type codedError struct {
+	code int
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) ErrorCode() int {
+	return e.code
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// wrapError wraps err with msg, attaching a JSON-RPC error code if err is known.
+func wrapError(msg string, err error) error {
+	wrapped := fmt.Errorf("%s: %w", msg, err)
+	code, ok := errorCode(err)
+	if !ok {
+		return wrapped
+	}
+	return &codedError{code: code, err: wrapped}
+}
+
+func errorCode(err error) (int, bool) {
+	var (
+		errSyntax              *sqlparser.ErrSyntaxError
+		errEmptyStatement      *parsing.ErrEmptyStatement
+		errMultiTable          *parsing.ErrMultiTableReference
+		errSystemTable         *parsing.ErrSystemTableReferencing
+		errNotSupported        *parsing.ErrStatementIsNotSupported
+		errInvalidRole         *parsing.ErrInvalidRole
+		errNoTopLevelCreate    *parsing.ErrNoTopLevelCreate
+		errNoTopLevelGrant     *parsing.ErrNoTopLevelGrant
+		errInvalidTableName    *parsing.ErrInvalidTableName
+		errPrefixTableName     *parsing.ErrPrefixTableName
+		errChainMismatch       *parsing.ErrInsertWithSelectChainMistmatch
+		errMixedOperations     *parsing.ErrMixedOperations
+		errSchemaQualified     *parsing.ErrSchemaQualifiedName
+		errColumnCount         *parsing.ErrColumnCountMismatch
+		errNonDeterministic    *parsing.ErrNonDeterministicLimit
+		errReadQueryTooLong    *parsing.ErrReadQueryTooLong
+		errWriteQueryTooLong   *parsing.ErrWriteQueryTooLong
+		errUnsupportedChain    *tableland.ErrUnsupportedChain
+		errTooManyRows         *sqlstore.ErrTooManyRows
+		errQueryTooCostly      *sqlstore.ErrQueryTooCostly
+		errPoolExhausted       *sqlstore.ErrPoolExhausted
+		errStatementNotAllowed = []interface{}{
+			&errEmptyStatement,
+			&errMultiTable,
+			&errSystemTable,
+			&errNotSupported,
+			&errInvalidRole,
+			&errNoTopLevelCreate,
+			&errNoTopLevelGrant,
+			&errInvalidTableName,
+			&errPrefixTableName,
+			&errChainMismatch,
+			&errMixedOperations,
+			&errSchemaQualified,
+			&errColumnCount,
+			&errNonDeterministic,
+		}
+	)
+
+	switch {
+	case errors.As(err, &errSyntax):
+		return ErrCodeInvalidStatement, true
+	case errors.As(err, &errReadQueryTooLong), errors.As(err, &errWriteQueryTooLong):
+		return ErrCodeQueryTooLong, true
+	case errors.As(err, &errUnsupportedChain):
+		return ErrCodeUnsupportedChain, true
+	case errors.As(err, &errTooManyRows):
+		return ErrCodeTooManyRows, true
+	case errors.As(err, &errQueryTooCostly):
+		return ErrCodeQueryTooCostly, true
+	case errors.As(err, &errPoolExhausted):
+		return ErrCodePoolExhausted, true
+	case errors.Is(err, sqlstore.ErrReadTimeout):
+		return ErrCodeReadTimeout, true
+	}
+	for _, target := range errStatementNotAllowed {
+		if errors.As(err, target) {
+			return ErrCodeStatementNotAllowed, true
+		}
+	}
+	return 0, false
+}
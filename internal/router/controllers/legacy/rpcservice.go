@@ -117,6 +117,9 @@ type SetControllerResponse struct {
 // RPCService provides the JSON RPC API.
 type RPCService struct {
 	tbl tableland.Tableland
+
+	// connCtx is the context of the websocket connection request, if any.
+	connCtx context.Context
 }
 
 // NewRPCService creates a new RPCService.
@@ -132,6 +135,7 @@ func (rs *RPCService) ValidateCreateTable(
 	ctx context.Context,
 	req ValidateCreateTableRequest,
 ) (ValidateCreateTableResponse, error) {
+	ctx = rs.withConnValues(ctx)
 	ctxChainID := ctx.Value(middlewares.ContextKeyChainID)
 	chainID, ok := ctxChainID.(tableland.ChainID)
 	if !ok {
@@ -139,7 +143,7 @@ func (rs *RPCService) ValidateCreateTable(
 	}
 	hash, err := rs.tbl.ValidateCreateTable(ctx, chainID, req.CreateStatement)
 	if err != nil {
-		return ValidateCreateTableResponse{}, fmt.Errorf("calling ValidateCreateTable %v", err)
+		return ValidateCreateTableResponse{}, wrapError("calling ValidateCreateTable", err)
 	}
 	return ValidateCreateTableResponse{StructureHash: hash}, nil
 }
@@ -149,6 +153,7 @@ func (rs *RPCService) ValidateWriteQuery(
 	ctx context.Context,
 	req ValidateWriteQueryRequest,
 ) (ValidateWriteQueryResponse, error) {
+	ctx = rs.withConnValues(ctx)
 	ctxChainID := ctx.Value(middlewares.ContextKeyChainID)
 	chainID, ok := ctxChainID.(tableland.ChainID)
 	if !ok {
@@ -156,7 +161,7 @@ func (rs *RPCService) ValidateWriteQuery(
 	}
 	tableID, err := rs.tbl.ValidateWriteQuery(ctx, chainID, req.Statement)
 	if err != nil {
-		return ValidateWriteQueryResponse{}, fmt.Errorf("calling ValidateWriteQuery: %v", err)
+		return ValidateWriteQueryResponse{}, wrapError("calling ValidateWriteQuery", err)
 	}
 	return ValidateWriteQueryResponse{TableID: tableID.String()}, nil
 }
@@ -166,6 +171,7 @@ func (rs *RPCService) RelayWriteQuery(
 	ctx context.Context,
 	req RelayWriteQueryRequest,
 ) (RelayWriteQueryResponse, error) {
+	ctx = rs.withConnValues(ctx)
 	ctxChainID := ctx.Value(middlewares.ContextKeyChainID)
 	chainID, ok := ctxChainID.(tableland.ChainID)
 	if !ok {
@@ -178,7 +184,7 @@ func (rs *RPCService) RelayWriteQuery(
 	}
 	txn, err := rs.tbl.RelayWriteQuery(ctx, chainID, common.HexToAddress(caller), req.Statement)
 	if err != nil {
-		return RelayWriteQueryResponse{}, fmt.Errorf("calling RelayWriteQuery: %v", err)
+		return RelayWriteQueryResponse{}, wrapError("calling RelayWriteQuery", err)
 	}
 	ret := RelayWriteQueryResponse{}
 	ret.Transaction.Hash = txn.Hash().Hex()
@@ -190,10 +196,11 @@ func (rs *RPCService) RunReadQuery(
 	ctx context.Context,
 	req RunReadQueryRequest,
 ) (RunReadQueryResponse, error) {
+	ctx = rs.withConnValues(ctx)
 	start := time.Now()
 	res, err := rs.tbl.RunReadQuery(ctx, req.Statement)
 	if err != nil {
-		return RunReadQueryResponse{}, fmt.Errorf("calling RunReadQuery: %v", err)
+		return RunReadQueryResponse{}, wrapError("calling RunReadQuery", err)
 	}
 	took := time.Since(start)
 
@@ -210,6 +217,9 @@ func (rs *RPCService) RunReadQuery(
 	if config.Unwrap && len(res.Rows) > 1 {
 		return RunReadQueryResponse{}, errors.New("unwrapped results with more than one row aren't supported in JSON RPC API")
 	}
+	if config.Output == formatter.CSV {
+		return RunReadQueryResponse{}, errors.New("csv output isn't supported in JSON RPC API")
+	}
 
 	controllers.CollectReadQueryMetric(ctx, req.Statement, config, took)
 
@@ -221,6 +231,7 @@ func (rs *RPCService) GetReceipt(
 	ctx context.Context,
 	req GetReceiptRequest,
 ) (GetReceiptResponse, error) {
+	ctx = rs.withConnValues(ctx)
 	ctxChainID := ctx.Value(middlewares.ContextKeyChainID)
 	chainID, ok := ctxChainID.(tableland.ChainID)
 	if !ok {
@@ -228,7 +239,7 @@ func (rs *RPCService) GetReceipt(
 	}
 	ok, receipt, err := rs.tbl.GetReceipt(ctx, chainID, req.TxnHash)
 	if err != nil {
-		return GetReceiptResponse{}, fmt.Errorf("calling GetReceipt: %v", err)
+		return GetReceiptResponse{}, wrapError("calling GetReceipt", err)
 	}
 	ret := GetReceiptResponse{Ok: ok}
 	if ok {
@@ -249,6 +260,7 @@ func (rs *RPCService) SetController(
 	ctx context.Context,
 	req SetControllerRequest,
 ) (SetControllerResponse, error) {
+	ctx = rs.withConnValues(ctx)
 	ctxChainID := ctx.Value(middlewares.ContextKeyChainID)
 	chainID, ok := ctxChainID.(tableland.ChainID)
 	if !ok {
@@ -270,9 +282,18 @@ func (rs *RPCService) SetController(
 		tableID,
 	)
 	if err != nil {
-		return SetControllerResponse{}, fmt.Errorf("calling SetController: %v", err)
+		return SetControllerResponse{}, wrapError("calling SetController", err)
 	}
 	ret := SetControllerResponse{}
 	ret.Transaction.Hash = txn.Hash().Hex()
 	return ret, nil
 }
+
+// withConnValues returns ctx with access to the values of the websocket
+// connection request, e.g: the chain id and caller address.
+func (rs *RPCService) withConnValues(ctx context.Context) context.Context {
+	if rs.connCtx == nil {
+		return ctx
+	}
+	return &connValuesCtx{Context: ctx, conn: rs.connCtx}
+}
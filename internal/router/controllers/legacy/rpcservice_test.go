@@ -1,17 +1,27 @@
 package legacy
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/mocks"
+	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/siwe"
+	"github.com/textileio/go-tableland/pkg/wallet"
 )
 
 func TestRunReadQueryManyRows(t *testing.T) {
@@ -159,3 +169,96 @@ func TestRunReadQueryUnwrap(t *testing.T) {
 	expJSON := `{"jsonrpc":"2.0","id":1,"result":{"data":{"age":40,"name":"bob"}}}`
 	require.JSONEq(t, expJSON, rr.Body.String())
 }
+
+func TestErrorCodes(t *testing.T) {
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	tbl := mocks.NewTableland(t)
+	for _, stmt := range []string{"CREATE TABLE foo_69 (a VARCHAR)", "CREATE TABLE system_foo_69 (a INT)"} {
+		_, err := parser.ValidateCreateTable(stmt, 69)
+		require.Error(t, err)
+		tbl.EXPECT().ValidateCreateTable(mock.Anything, tableland.ChainID(69), stmt).Return(
+			"",
+			fmt.Errorf("parsing create table statement: %w", err),
+		)
+	}
+	tbl.EXPECT().RunReadQuery(mock.Anything, "SELECT * FROM bruno_69_7").Return(
+		nil,
+		fmt.Errorf("running read statement: %w", &tableland.ErrUnsupportedChain{ChainID: 69}),
+	)
+
+	rpcService := NewRPCService(tbl)
+
+	server := rpc.NewServer()
+	err = server.RegisterName("tableland", rpcService)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Handle("/rpc", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middlewares.ContextKeyChainID, tableland.ChainID(69))
+		server.ServeHTTP(w, r.WithContext(ctx))
+	}))
+
+	call := func(in string) string {
+		req, err := http.NewRequest("POST", "/rpc", strings.NewReader(in))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	// Invalid column type
+	out := call(`{"jsonrpc":"2.0","method":"tableland_validateCreateTable","id":1,"params":[{"create_statement":"CREATE TABLE foo_69 (a VARCHAR)"}]}`) // nolint
+	require.Contains(t, out, fmt.Sprintf(`"code":%d`, ErrCodeInvalidStatement))
+
+	// Statement not allowed
+	out = call(`{"jsonrpc":"2.0","method":"tableland_validateCreateTable","id":1,"params":[{"create_statement":"CREATE TABLE system_foo_69 (a INT)"}]}`) // nolint
+	require.Contains(t, out, fmt.Sprintf(`"code":%d`, ErrCodeStatementNotAllowed))
+
+	// Known error wrapped by the service
+	out = call(`{"jsonrpc":"2.0","method":"tableland_runReadQuery","id":1,"params":[{"statement":"SELECT * FROM bruno_69_7"}]}`) // nolint
+	require.Contains(t, out, fmt.Sprintf(`"code":%d`, ErrCodeUnsupportedChain))
+}
+
+func TestWebsocket(t *testing.T) {
+	tbl := mocks.NewTableland(t)
+	tbl.EXPECT().ValidateCreateTable(mock.Anything, tableland.ChainID(69), "CREATE TABLE foo_69 (a INT)").Return(
+		"0xabcd",
+		nil,
+	)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewWallet(hex.EncodeToString(crypto.FromECDSA(key)))
+	require.NoError(t, err)
+	token, err := siwe.EncodedSIWEMsg(69, w, time.Hour)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Handle("/rpc/ws", middlewares.WebsocketAuthentication(NewWebsocketHandler(tbl, nil)))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	endpoint := "ws" + strings.TrimPrefix(server.URL, "http") + "/rpc/ws"
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	conn, _, err := websocket.DefaultDialer.Dial(endpoint, header)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tableland_validateCreateTable",
+		"params":  []interface{}{ValidateCreateTableRequest{CreateStatement: "CREATE TABLE foo_69 (a INT)"}},
+	}))
+	var resp struct {
+		Result ValidateCreateTableResponse `json:"result"`
+	}
+	require.NoError(t, conn.ReadJSON(&resp))
+	require.Equal(t, "0xabcd", resp.Result.StructureHash)
+}
@@ -0,0 +1,46 @@
+package legacy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+	"github.com/textileio/go-tableland/internal/tableland"
+)
+
+// NewWebsocketHandler returns a handler that serves the JSON-RPC API over websockets.
+// Connections from browsers are only accepted from allowedOrigins. If it's empty, only
+// localhost is allowed. Use "*" to accept any origin.
+func NewWebsocketHandler(tbl tableland.Tableland, allowedOrigins []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The rpc server doesn't propagate the upgrade request context to the calls made
+		// on the connection, so each connection gets its own service bound to it.
+		server := rpc.NewServer()
+		defer server.Stop()
+		rpcService := &RPCService{
+			tbl:     tbl,
+			connCtx: r.Context(),
+		}
+		if err := server.RegisterName("tableland", rpcService); err != nil {
+			log.Error().Err(err).Msg("registering json-rpc service for websocket connection")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		server.WebsocketHandler(allowedOrigins).ServeHTTP(w, r)
+	})
+}
+
+// connValuesCtx is a context that resolves values missing in the call context
+// from the context of the websocket connection request.
+type connValuesCtx struct {
+	context.Context
+	conn context.Context
+}
+
+func (c *connValuesCtx) Value(key interface{}) interface{} {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.conn.Value(key)
+}
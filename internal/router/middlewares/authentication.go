@@ -74,6 +74,36 @@ func Authentication(next http.Handler) http.Handler {
 	})
 }
 
+// WebsocketAuthentication is middleware that provides SIWE authentication for websocket connections.
+// The token is only read from the Authorization header, since query parameters end up in logs.
+// Connections without a token are accepted, but only methods that don't require authentication
+// can be called on them.
+func WebsocketAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorization := r.Header.Get("Authorization"); authorization != "" {
+			parts := strings.Split(authorization, "Bearer ")
+			if len(parts) != 2 {
+				w.Header().Set("Content-type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(errors.ServiceError{Message: "malformed authorization header provided"})
+				return
+			}
+
+			chainID, issuer, err := parseAuth(parts[1])
+			if err != nil {
+				w.Header().Set("Content-type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(errors.ServiceError{Message: fmt.Sprintf("parsing authorization: %v", err)})
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), ContextKeyAddress, strings.ToLower(issuer)))
+			r = r.WithContext(context.WithValue(r.Context(), ContextKeyChainID, chainID))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func parseAuth(bearerToken string) (tableland.ChainID, string, error) {
 	var siweAuthMsg struct {
 		Message   string `json:"message"`
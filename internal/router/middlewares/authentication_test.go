@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/spruceid/siwe-go"
@@ -84,3 +85,62 @@ func TestOptionality(t *testing.T) {
 		})
 	}
 }
+
+func TestWebsocketAuthentication(t *testing.T) {
+	t.Parallel()
+
+	siweToken := "eyJtZXNzYWdlIjoiVGFibGVsYW5kIHdhbnRzIHlvdSB0byBzaWduIGluIHdpdGggeW91ciBFdGhlcmV1bSBhY2NvdW50OlxuMHhkNTM1YkFkNTA0Q0RkNzdlMkM1MWRFMjZGNDE2NjkzREY3YTAxYWM4XG5cblNJV0UgTm90ZXBhZCBFeGFtcGxlXG5cblVSSTogaHR0cDovL2xvY2FsaG9zdDo0MzYxXG5WZXJzaW9uOiAxXG5DaGFpbiBJRDogNFxuTm9uY2U6IEhHVkJWMFdvYlFHb1ZWUUlzXG5Jc3N1ZWQgQXQ6IDIwMjItMDQtMTlUMTg6NDA6MDQuMDQ2WlxuRXhwaXJhdGlvbiBUaW1lOiAyMDUyLTA0LTE4VDE1OjA4OjE0LjgwNVoiLCJzaWduYXR1cmUiOiIweDk3NTFjNDI2MjNiYTZhNjc1OTA5YjEzMzVjZGI2NDc0ODU4MmY5OTMyMTQxOTBmZmM2MGE0OGRhN2UzOTNhMjcwMDkzMDgzZmRkMzI4ZTNkZjA2ODc3ZTY3MjQ2MWJhMjcwYmI2YjFiYmQxMGJmNTBiMTliMTg5MmExNDhiNzkzMWMifQ==" //nolint
+
+	tests := []struct {
+		name          string
+		authorization string
+		query         string
+		expStatusCode int
+		expAddress    interface{}
+	}{
+		{
+			name:          "header",
+			authorization: "Bearer " + siweToken,
+			expStatusCode: http.StatusOK,
+			expAddress:    "0xd535bad504cdd77e2c51de26f416693df7a01ac8",
+		},
+		{
+			name:          "query parameter is ignored",
+			query:         "?auth=" + url.QueryEscape(siweToken),
+			expStatusCode: http.StatusOK,
+			expAddress:    nil,
+		},
+		{
+			name:          "no token",
+			expStatusCode: http.StatusOK,
+			expAddress:    nil,
+		},
+		{
+			name:          "malformed header",
+			authorization: siweToken,
+			expStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var address interface{}
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				address = r.Context().Value(ContextKeyAddress)
+			})
+
+			r := httptest.NewRequest("GET", "/rpc/ws"+tc.query, nil)
+			if tc.authorization != "" {
+				r.Header.Set("Authorization", tc.authorization)
+			}
+			rw := httptest.NewRecorder()
+
+			WebsocketAuthentication(next).ServeHTTP(rw, r)
+
+			require.Equal(t, tc.expStatusCode, rw.Code)
+			require.Equal(t, tc.expAddress, address)
+		})
+	}
+}
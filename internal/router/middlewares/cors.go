@@ -2,19 +2,68 @@ package middlewares
 
 import (
 	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	defaultCORSAllowedMethods = []string{"GET", "POST", "OPTIONS"}
+	defaultCORSAllowedHeaders = []string{"Accept", "Accept-Language", "Content-Type", "Authorization"}
 )
 
-// CORS sets the correct headers for allowing cross origin requests.
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Accept-Language, Content-Type, Authorization")
+// CORSConfig contains configuration for the CORS middleware.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to make cross origin requests. Use "*" to allow
+	// any origin. If empty, only same-origin requests are allowed.
+	AllowedOrigins []string
+	// AllowedMethods defaults to GET, POST and OPTIONS if empty.
+	AllowedMethods []string
+	// AllowedHeaders defaults to Accept, Accept-Language, Content-Type and Authorization if empty.
+	AllowedHeaders []string
+}
 
-		if r.Method == "OPTIONS" {
-			return
+// CORS returns a middleware that sets the correct headers for allowing cross origin requests
+// from the configured origins. Preflight OPTIONS requests are answered without calling the next handler.
+func CORS(cfg CORSConfig) mux.MiddlewareFunc {
+	allowAny := false
+	origins := make(map[string]struct{}, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
 		}
+		origins[strings.ToLower(origin)] = struct{}{}
+	}
+	methods := cfg.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSAllowedMethods
+	}
+	headers := cfg.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSAllowedHeaders
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(headers, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if allowAny {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else if origin != "" {
+				w.Header().Add("Vary", "Origin")
+				if _, ok := origins[strings.ToLower(origin)]; ok {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+				}
+			}
+			w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+
+			if r.Method == http.MethodOptions {
+				return
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
 }
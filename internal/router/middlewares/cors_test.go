@@ -0,0 +1,94 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name           string
+		cfg            CORSConfig
+		origin         string
+		expAllowOrigin string
+	}
+
+	tests := []testCase{
+		{
+			name:           "allowed origin",
+			cfg:            CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			origin:         "https://app.example.com",
+			expAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:           "disallowed origin",
+			cfg:            CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			origin:         "https://evil.example.com",
+			expAllowOrigin: "",
+		},
+		{
+			name:           "no configured origins",
+			cfg:            CORSConfig{},
+			origin:         "https://app.example.com",
+			expAllowOrigin: "",
+		},
+		{
+			name:           "any origin",
+			cfg:            CORSConfig{AllowedOrigins: []string{"*"}},
+			origin:         "https://app.example.com",
+			expAllowOrigin: "*",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tc testCase) func(t *testing.T) {
+			return func(t *testing.T) {
+				t.Parallel()
+
+				var called bool
+				h := CORS(tc.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					called = true
+				}))
+
+				r := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+				r.Header.Set("Origin", tc.origin)
+				rr := httptest.NewRecorder()
+				h.ServeHTTP(rr, r)
+
+				require.True(t, called)
+				require.Equal(t, tc.expAllowOrigin, rr.Header().Get("Access-Control-Allow-Origin"))
+			}
+		}(tc))
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	t.Parallel()
+
+	cfg := CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type"},
+	}
+	var called bool
+	h := CORS(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	r := httptest.NewRequest(http.MethodOptions, "/rpc", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, r)
+
+	require.False(t, called)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "GET, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
+	require.Equal(t, "Content-Type", rr.Header().Get("Access-Control-Allow-Headers"))
+}
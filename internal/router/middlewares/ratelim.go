@@ -24,6 +24,10 @@ type RateLimiterConfig struct {
 
 	JSONRPCRoute        string
 	JSONRPCMethodLimits map[string]RateLimiterRouteConfig
+
+	// TrustedProxies are the IPs or CIDRs of the proxies allowed to set the X-Forwarded-For header.
+	// If empty, the X-Forwarded-For header is ignored and the connection remote address is used.
+	TrustedProxies []string
 }
 
 // RateLimiterRouteConfig specifies the maximum request per interval, and
@@ -36,9 +40,15 @@ type RateLimiterRouteConfig struct {
 // RateLimitController creates a new middleware to rate limit requests.
 // It applies a priority based rate limiting key for the rate limiting:
 // 1. A "chain-address" was detected (i.e: via a signed SIWE).
-// 2. If 1. isn't present, it will use an existing X-Forwarded-For IP included by a load-balancer in the infrastructure.
-// 3. If 2. isn't present, it will use the connection remote address.
+// 2. If 1. isn't present and the request comes from one of the TrustedProxies, it will use the client IP in
+// the X-Forwarded-For header included by a load-balancer in the infrastructure.
+// 3. Otherwise, it will use the connection remote address.
 func RateLimitController(cfg RateLimiterConfig) (mux.MiddlewareFunc, error) {
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("parsing trusted proxies: %s", err)
+	}
+
 	keyFunc := func(r *http.Request) (string, error) {
 		// Use a chain address if present.
 		address := r.Context().Value(ContextKeyAddress)
@@ -47,7 +57,7 @@ func RateLimitController(cfg RateLimiterConfig) (mux.MiddlewareFunc, error) {
 			return ctrlAddress, nil
 		}
 
-		ip, err := extractClientIP(r)
+		ip, err := extractClientIPBehindProxies(r, trustedProxies)
 		if err != nil {
 			return "", fmt.Errorf("extract client ip: %s", err)
 		}
@@ -134,3 +144,66 @@ func extractClientIP(r *http.Request) (string, error) {
 	}
 	return ip, nil
 }
+
+// extractClientIPBehindProxies returns the client IP honoring the X-Forwarded-For header only if the
+// request comes from a trusted proxy. The header is walked from right to left, returning the first
+// IP that isn't a trusted proxy, so a client can't spoof its IP by sending the header itself.
+func extractClientIPBehindProxies(r *http.Request, trusted []*net.IPNet) (string, error) {
+	remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "", fmt.Errorf("getting ip from remote addr: %s", err)
+	}
+	if !isTrustedProxy(remoteIP, trusted) {
+		return remoteIP, nil
+	}
+
+	xff := r.Header.Get("X-Forwarded-For")
+	if xff == "" {
+		return remoteIP, nil
+	}
+	ips := strings.Split(xff, ",")
+	for i := len(ips) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(ips[i])
+		if !isTrustedProxy(ip, trusted) {
+			return ip, nil
+		}
+	}
+	return strings.TrimSpace(ips[0]), nil
+}
+
+func isTrustedProxy(ip string, trusted []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range trusted {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, len(proxies))
+	for i, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets[i] = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %s: %s", proxy, err)
+		}
+		nets[i] = ipNet
+	}
+	return nets, nil
+}
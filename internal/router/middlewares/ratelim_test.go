@@ -164,6 +164,9 @@ func TestLimit1IP(t *testing.T) {
 					},
 					JSONRPCRoute: "/rpc",
 				}
+				if tc.forwardedFor {
+					cfg.TrustedProxies = []string{"10.0.0.1"}
+				}
 				rlcm, err := RateLimitController(cfg)
 				require.NoError(t, err)
 				rlc := rlcm(dummyHandler{})
@@ -173,6 +176,7 @@ func TestLimit1IP(t *testing.T) {
 				require.NoError(t, err)
 
 				if tc.forwardedFor {
+					r.RemoteAddr = "10.0.0.1:1234"
 					r.Header.Set("X-Forwarded-For", uuid.NewString())
 				} else {
 					r.RemoteAddr = uuid.NewString() + ":1234"
@@ -258,6 +262,127 @@ func TestRateLim10IPs(t *testing.T) {
 	}
 }
 
+func TestRateLimTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	cfg := RateLimiterConfig{
+		Default: RateLimiterRouteConfig{
+			MaxRPI:   10,
+			Interval: time.Minute,
+		},
+		JSONRPCRoute:   "/rpc",
+		TrustedProxies: []string{"10.0.0.0/8", "192.168.0.1"},
+	}
+	rlcm, err := RateLimitController(cfg)
+	require.NoError(t, err)
+	rlc := rlcm(dummyHandler{})
+
+	// A client that isn't a trusted proxy can't avoid the limit by spoofing X-Forwarded-For.
+	var limited bool
+	for i := 0; i < 20; i++ {
+		r, err := http.NewRequest("POST", "/rpc", bytes.NewReader([]byte(`{"method": "tableland_runReadQuery"}`)))
+		require.NoError(t, err)
+		r.RemoteAddr = "1.2.3.4:1234"
+		r.Header.Set("X-Forwarded-For", uuid.NewString())
+
+		res := httptest.NewRecorder()
+		rlc.ServeHTTP(res, r)
+		if res.Code == 429 {
+			limited = true
+			break
+		}
+	}
+	require.True(t, limited)
+
+	// Different clients behind trusted proxies are limited independently.
+	for i := 0; i < 100; i++ {
+		r, err := http.NewRequest("POST", "/rpc", bytes.NewReader([]byte(`{"method": "tableland_runReadQuery"}`)))
+		require.NoError(t, err)
+		r.RemoteAddr = "192.168.0.1:1234"
+		r.Header.Set("X-Forwarded-For", fmt.Sprintf("5.6.7.%d, 10.0.0.1", i))
+
+		res := httptest.NewRecorder()
+		rlc.ServeHTTP(res, r)
+		require.Equal(t, 200, res.Code)
+	}
+}
+
+func TestRateLimIgnoresForwardedForWithoutTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	cfg := RateLimiterConfig{
+		Default: RateLimiterRouteConfig{
+			MaxRPI:   10,
+			Interval: time.Minute,
+		},
+		JSONRPCRoute: "/rpc",
+	}
+	rlcm, err := RateLimitController(cfg)
+	require.NoError(t, err)
+	rlc := rlcm(dummyHandler{})
+
+	// Without trusted proxies, a client can't avoid the limit by sending different X-Forwarded-For values.
+	var limited bool
+	for i := 0; i < 20; i++ {
+		r, err := http.NewRequest("POST", "/rpc", bytes.NewReader([]byte(`{"method": "tableland_runReadQuery"}`)))
+		require.NoError(t, err)
+		r.RemoteAddr = "1.2.3.4:1234"
+		r.Header.Set("X-Forwarded-For", fmt.Sprintf("5.6.7.%d", i))
+
+		res := httptest.NewRecorder()
+		rlc.ServeHTTP(res, r)
+		if res.Code == 429 {
+			limited = true
+			break
+		}
+	}
+	require.True(t, limited)
+}
+
+func TestExtractClientIPBehindProxies(t *testing.T) {
+	t.Parallel()
+
+	trusted, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.0.1"})
+	require.NoError(t, err)
+
+	type testCase struct {
+		name       string
+		remoteAddr string
+		xff        string
+		expIP      string
+	}
+
+	tests := []testCase{
+		{name: "untrusted remote", remoteAddr: "1.2.3.4:1234", xff: "5.6.7.8", expIP: "1.2.3.4"},
+		{name: "trusted remote without header", remoteAddr: "10.1.1.1:1234", expIP: "10.1.1.1"},
+		{name: "trusted remote", remoteAddr: "10.1.1.1:1234", xff: "5.6.7.8", expIP: "5.6.7.8"},
+		{name: "spoofed header", remoteAddr: "10.1.1.1:1234", xff: "9.9.9.9, 5.6.7.8, 192.168.0.1", expIP: "5.6.7.8"},
+		{name: "all trusted", remoteAddr: "10.1.1.1:1234", xff: "10.0.0.2, 10.0.0.3", expIP: "10.0.0.2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(tc testCase) func(t *testing.T) {
+			return func(t *testing.T) {
+				t.Parallel()
+
+				r, err := http.NewRequest("GET", "/", nil)
+				require.NoError(t, err)
+				r.RemoteAddr = tc.remoteAddr
+				if tc.xff != "" {
+					r.Header.Set("X-Forwarded-For", tc.xff)
+				}
+
+				ip, err := extractClientIPBehindProxies(r, trusted)
+				require.NoError(t, err)
+				require.Equal(t, tc.expIP, ip)
+			}
+		}(tc))
+	}
+
+	_, err = parseTrustedProxies([]string{"not-an-ip"})
+	require.Error(t, err)
+}
+
 type dummyHandler struct{}
 
 func (dh dummyHandler) ServeHTTP(_ http.ResponseWriter, _ *http.Request) {
@@ -18,10 +18,16 @@ import (
 // ConfiguredRouter returns a fully configured Router that can be used as an http handler.
 func ConfiguredRouter(
 	tableland tableland.Tableland,
+	tablelandImpl string,
 	systemService system.SystemService,
+	db controllers.Pinger,
+	readiness controllers.ReadinessChecker,
 	maxRPI uint64,
 	rateLimInterval time.Duration,
+	rateLimTrustedProxies []string,
 	supportedChainIDs []tableland.ChainID,
+	wsAllowedOrigins []string,
+	corsConfig middlewares.CORSConfig,
 ) (*Router, error) {
 	rpcService := legacy.NewRPCService(tableland)
 	server := rpc.NewServer()
@@ -31,14 +37,15 @@ func ConfiguredRouter(
 
 	// General router configuration.
 	router := newRouter()
-	router.use(middlewares.CORS, middlewares.TraceID)
+	router.use(middlewares.CORS(corsConfig), middlewares.TraceID)
 
 	cfg := middlewares.RateLimiterConfig{
 		Default: middlewares.RateLimiterRouteConfig{
 			MaxRPI:   maxRPI,
 			Interval: rateLimInterval,
 		},
-		JSONRPCRoute: "/rpc", // TODO(json-rpc): remove this feature in the rate-limiter when we drop support.
+		JSONRPCRoute:   "/rpc", // TODO(json-rpc): remove this feature in the rate-limiter when we drop support.
+		TrustedProxies: rateLimTrustedProxies,
 	}
 	rateLim, err := middlewares.RateLimitController(cfg)
 	if err != nil {
@@ -49,10 +56,16 @@ func ConfiguredRouter(
 
 	// TODO(json-rpc): remove this when dropping support.
 	// APIs Legacy (REST + JSON-RPC)
-	configureLegacyRoutes(router, server, supportedChainIDs, rateLim, ctrl)
+	healthHandler := controllers.NewHealthHandler(db, tablelandImpl)
+	configureLegacyRoutes(router, server, supportedChainIDs, rateLim, ctrl, healthHandler)
+	router.get("/ready", controllers.NewReadyHandler(readiness))
+
+	// JSON-RPC over websockets.
+	wsHandler := legacy.NewWebsocketHandler(tableland, wsAllowedOrigins)
+	router.get("/rpc/ws", wsHandler.ServeHTTP, middlewares.WebsocketAuthentication, rateLim)
 
 	// APIs V1
-	if err := configureAPIV1Routes(router, supportedChainIDs, rateLim, ctrl); err != nil {
+	if err := configureAPIV1Routes(router, supportedChainIDs, rateLim, ctrl, healthHandler); err != nil {
 		return nil, fmt.Errorf("configuring API v1: %s", err)
 	}
 
@@ -65,6 +78,7 @@ func configureLegacyRoutes(
 	supportedChainIDs []tableland.ChainID,
 	rateLim mux.MiddlewareFunc,
 	ctrl *controllers.Controller,
+	healthHandler http.HandlerFunc,
 ) {
 	router.post("/rpc", func(rw http.ResponseWriter, r *http.Request) {
 		server.ServeHTTP(rw, r)
@@ -81,8 +95,8 @@ func configureLegacyRoutes(
 	router.get("/version", ctrl.Version, middlewares.WithLogging, middlewares.OtelHTTP("Version"), rateLim)           // nolint
 
 	// Health endpoint configuration.
-	router.get("/healthz", controllers.HealthHandler)
-	router.get("/health", controllers.HealthHandler)
+	router.get("/healthz", healthHandler)
+	router.get("/health", healthHandler)
 }
 
 func configureAPIV1Routes(
@@ -90,6 +104,7 @@ func configureAPIV1Routes(
 	supportedChainIDs []tableland.ChainID,
 	rateLim mux.MiddlewareFunc,
 	userCtrl *controllers.Controller,
+	healthHandler http.HandlerFunc,
 ) error {
 	handlers := map[string]struct {
 		handler     http.HandlerFunc
@@ -112,7 +127,7 @@ func configureAPIV1Routes(
 			[]mux.MiddlewareFunc{middlewares.WithLogging, rateLim},
 		},
 		"Health": {
-			controllers.HealthHandler,
+			healthHandler,
 			[]mux.MiddlewareFunc{middlewares.WithLogging, rateLim},
 		},
 	}
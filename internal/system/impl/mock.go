@@ -119,6 +119,16 @@ func (s *SystemMockService) GetSchemaByTableName(_ context.Context, _ string) (s
 	}, nil
 }
 
+// AreSameStructure returns true for any pair of tables.
+func (s *SystemMockService) AreSameStructure(
+	_ context.Context,
+	_ tableland.ChainID,
+	_ tables.TableID,
+	_ tables.TableID,
+) (bool, error) {
+	return true, nil
+}
+
 // SystemMockErrService is a dummy implementation that returns a fixed value.
 type SystemMockErrService struct{}
 
@@ -154,3 +164,13 @@ func (s *SystemMockErrService) GetTablesByStructure(_ context.Context, _ string)
 func (s *SystemMockErrService) GetSchemaByTableName(_ context.Context, _ string) (sqlstore.TableSchema, error) {
 	return sqlstore.TableSchema{}, errors.New("no table found")
 }
+
+// AreSameStructure returns an error.
+func (s *SystemMockErrService) AreSameStructure(
+	_ context.Context,
+	_ tableland.ChainID,
+	_ tables.TableID,
+	_ tables.TableID,
+) (bool, error) {
+	return false, errors.New("no table found")
+}
@@ -112,14 +112,13 @@ func (s *SystemSQLStoreService) GetTableMetadata(
 			Message:     "Table not found",
 		}, system.ErrTableNotFound
 	}
-	tableName := fmt.Sprintf("%s_%d_%s", table.Prefix, table.ChainID, table.ID)
-	schema, err := store.GetSchemaByTableName(ctx, tableName)
+	schema, err := store.GetSchemaByTableName(ctx, table.Name())
 	if err != nil {
 		return sqlstore.TableMetadata{}, fmt.Errorf("get table schema information: %s", err)
 	}
 
 	return sqlstore.TableMetadata{
-		Name:         tableName,
+		Name:         table.Name(),
 		ExternalURL:  fmt.Sprintf("%s/chain/%d/tables/%s", s.extURLPrefix, table.ChainID, table.ID),
 		Image:        s.getMetadataImage(table.ChainID, table.ID),
 		AnimationURL: s.getAnimationURL(table.ChainID, table.ID),
@@ -167,6 +166,7 @@ func (s *SystemSQLStoreService) GetReceiptByTransactionHash(
 }
 
 // GetTablesByController returns table's fetched from SQLStore by controller address.
+// The tables are ordered by creation time.
 func (s *SystemSQLStoreService) GetTablesByController(
 	ctx context.Context,
 	controller string,
@@ -227,6 +227,34 @@ func (s *SystemSQLStoreService) GetSchemaByTableName(
 	return schema, nil
 }
 
+// AreSameStructure returns true if both tables have the same structure hash. It fails with
+// *system.ErrTableNotExist if any of the tables doesn't exist.
+func (s *SystemSQLStoreService) AreSameStructure(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	id1 tables.TableID,
+	id2 tables.TableID,
+) (bool, error) {
+	store, ok := s.stores[chainID]
+	if !ok {
+		return false, fmt.Errorf("chain id %d isn't supported in the validator", chainID)
+	}
+
+	structures := make([]string, 0, 2)
+	for _, id := range []tables.TableID{id1, id2} {
+		table, err := store.GetTable(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, &system.ErrTableNotExist{ChainID: chainID, TableID: id}
+		}
+		if err != nil {
+			return false, fmt.Errorf("get table %s: %s", id, err)
+		}
+		structures = append(structures, table.Structure)
+	}
+
+	return structures[0] == structures[1], nil
+}
+
 func (s *SystemSQLStoreService) getMetadataImage(chainID tableland.ChainID, tableID tables.TableID) string {
 	if s.metadataRendererURI == "" {
 		return DefaultMetadataImage
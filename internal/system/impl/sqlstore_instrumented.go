@@ -127,6 +127,29 @@ func (s *InstrumentedSystemSQLStoreService) GetTablesByStructure(
 	return tables, err
 }
 
+// AreSameStructure returns true if both tables have the same structure hash.
+func (s *InstrumentedSystemSQLStoreService) AreSameStructure(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	id1 tables.TableID,
+	id2 tables.TableID,
+) (bool, error) {
+	start := time.Now()
+	same, err := s.system.AreSameStructure(ctx, chainID, id1, id2)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("AreSameStructure")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return same, err
+}
+
 // GetSchemaByTableName returns the schema of a table by its name.
 func (s *InstrumentedSystemSQLStoreService) GetSchemaByTableName(
 	ctx context.Context,
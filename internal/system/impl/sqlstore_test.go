@@ -100,6 +100,158 @@ func TestSystemSQLStoreService(t *testing.T) {
 	require.Equal(t, "5d70b398f938650871dd0d6d421e8d1d0c89fe9ed6c8a817c97e951186da7172", tables[0].Structure)
 }
 
+func TestGetTablesByController(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	ctx := context.WithValue(context.Background(), middlewares.ContextKeyChainID, tableland.ChainID(1337))
+	store, err := system.New(dbURI, chainID)
+	require.NoError(t, err)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	other := common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2")
+
+	// populate the registry with two tables for owner and one for other
+	ex, err := executor.NewExecutor(1337, db, parser, 0, nil)
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+
+	res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x0"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     owner,
+				Statement: "create table foo_1337 (bar int)",
+			},
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(2),
+				Owner:     other,
+				Statement: "create table baz_1337 (bar int)",
+			},
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(3),
+				Owner:     owner,
+				Statement: "create table qux_1337 (bar int)",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.Nil(t, res.ErrorEventIdx)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	stack := map[tableland.ChainID]sqlstore.SystemStore{1337: store}
+	svc, err := NewSystemSQLStoreService(stack, "https://tableland.network/tables", "https://render.tableland.xyz", "")
+	require.NoError(t, err)
+
+	tables, err := svc.GetTablesByController(ctx, owner.Hex())
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	require.Equal(t, "1", tables[0].ID.String())
+	require.Equal(t, "foo", tables[0].Prefix)
+	require.Equal(t, "3", tables[1].ID.String())
+	require.Equal(t, "qux", tables[1].Prefix)
+	for _, table := range tables {
+		require.Equal(t, chainID, table.ChainID)
+		require.Equal(t, owner.Hex(), table.Controller)
+	}
+
+	// make the first table the newest one, so the order must flip.
+	_, err = db.Exec("UPDATE registry SET created_at = created_at + 10 WHERE chain_id = 1337 AND id = 1")
+	require.NoError(t, err)
+
+	tables, err = svc.GetTablesByController(ctx, owner.Hex())
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	require.Equal(t, "3", tables[0].ID.String())
+	require.Equal(t, "1", tables[1].ID.String())
+}
+
+func TestAreSameStructure(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	ctx := context.Background()
+	store, err := system.New(dbURI, chainID)
+	require.NoError(t, err)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+
+	// populate the registry with two tables of the same structure and a different one
+	ex, err := executor.NewExecutor(1337, db, parser, 0, nil)
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+
+	res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x0"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     owner,
+				Statement: "create table foo_1337 (bar int)",
+			},
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(2),
+				Owner:     owner,
+				Statement: "create table baz_1337 (bar int)",
+			},
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(3),
+				Owner:     owner,
+				Statement: "create table qux_1337 (bar text)",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.Nil(t, res.ErrorEventIdx)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	stack := map[tableland.ChainID]sqlstore.SystemStore{1337: store}
+	svc, err := NewSystemSQLStoreService(stack, "https://tableland.network/tables", "https://render.tableland.xyz", "")
+	require.NoError(t, err)
+
+	id1, _ := tables.NewTableID("1")
+	id2, _ := tables.NewTableID("2")
+	id3, _ := tables.NewTableID("3")
+	id4, _ := tables.NewTableID("4")
+
+	same, err := svc.AreSameStructure(ctx, chainID, id1, id2)
+	require.NoError(t, err)
+	require.True(t, same)
+
+	same, err = svc.AreSameStructure(ctx, chainID, id1, id3)
+	require.NoError(t, err)
+	require.False(t, same)
+
+	_, err = svc.AreSameStructure(ctx, chainID, id1, id4)
+	var errNotExist *sys.ErrTableNotExist
+	require.ErrorAs(t, err, &errNotExist)
+	require.Equal(t, id4, errNotExist.TableID)
+	require.ErrorIs(t, err, sys.ErrTableNotFound)
+}
+
 func TestGetSchemaByTableName(t *testing.T) {
 	t.Parallel()
 
@@ -149,11 +301,13 @@ func TestGetSchemaByTableName(t *testing.T) {
 
 	require.Equal(t, "a", schema.Columns[0].Name)
 	require.Equal(t, "integer", schema.Columns[0].Type)
+	require.False(t, schema.Columns[0].NotNull)
 	require.Len(t, schema.Columns[0].Constraints, 1)
 	require.Equal(t, "primary key autoincrement", schema.Columns[0].Constraints[0])
 
 	require.Equal(t, "b", schema.Columns[1].Name)
 	require.Equal(t, "text", schema.Columns[1].Type)
+	require.True(t, schema.Columns[1].NotNull)
 	require.Len(t, schema.Columns[1].Constraints, 3)
 	require.Equal(t, "not null", schema.Columns[1].Constraints[0])
 	require.Equal(t, "default 'foo'", schema.Columns[1].Constraints[1])
@@ -3,8 +3,10 @@ package system
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/pkg/sqlstore"
 	"github.com/textileio/go-tableland/pkg/tables"
 )
@@ -12,6 +14,22 @@ import (
 // ErrTableNotFound indicates that the table doesn't exist.
 var ErrTableNotFound = errors.New("table not found")
 
+// ErrTableNotExist is an error returned when a table id doesn't exist in a chain.
+// It matches ErrTableNotFound with errors.Is.
+type ErrTableNotExist struct {
+	ChainID tableland.ChainID
+	TableID tables.TableID
+}
+
+func (e *ErrTableNotExist) Error() string {
+	return fmt.Sprintf("table id %s doesn't exist in chain %d", e.TableID, e.ChainID)
+}
+
+// Is reports whether target is ErrTableNotFound.
+func (e *ErrTableNotExist) Is(target error) bool {
+	return target == ErrTableNotFound
+}
+
 // SystemService defines what system operations can be done.
 // TODO(json-rpc): this interface should be cleaned up after dropping support.
 type SystemService interface {
@@ -20,4 +38,5 @@ type SystemService interface {
 	GetTablesByStructure(context.Context, string) ([]sqlstore.Table, error)
 	GetSchemaByTableName(context.Context, string) (sqlstore.TableSchema, error)
 	GetReceiptByTransactionHash(context.Context, common.Hash) (sqlstore.Receipt, bool, error)
+	AreSameStructure(context.Context, tableland.ChainID, tables.TableID, tables.TableID) (bool, error)
 }
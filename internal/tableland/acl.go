@@ -3,7 +3,9 @@ package tableland
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/pkg/tables"
@@ -39,6 +41,12 @@ var (
 		Abbreviation: "d",
 		Bitfield:     0b100,
 	}
+
+	// PrivCreate allows tables to be created, if table creation is gated. The abbreviation is "c".
+	PrivCreate = Privilege{
+		Abbreviation: "c",
+		Bitfield:     0b1000,
+	}
 )
 
 // NewPrivilegeFromSQLString converts a SQL privilege string into a Privilege.
@@ -50,6 +58,8 @@ func NewPrivilegeFromSQLString(s string) (Privilege, error) {
 		return PrivUpdate, nil
 	case "delete":
 		return PrivDelete, nil
+	case "create":
+		return PrivCreate, nil
 	}
 
 	return Privilege{}, fmt.Errorf("unsupported string=%s", s)
@@ -64,11 +74,45 @@ func (p Privilege) ToSQLString() string {
 		return "update"
 	case PrivDelete:
 		return "delete"
+	case PrivCreate:
+		return "create"
 	default:
 		return "nil"
 	}
 }
 
+// MarshalJSON returns the JSON encoding of the privilege as its SQL string representation.
+func (p Privilege) MarshalJSON() ([]byte, error) {
+	s := p.ToSQLString()
+	if s == "nil" {
+		return nil, &ErrUnknownPrivilege{Value: fmt.Sprintf("%s (%b)", p.Abbreviation, p.Bitfield)}
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON parses a privilege from its JSON encoded SQL string representation.
+func (p *Privilege) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &ErrUnknownPrivilege{Value: string(data)}
+	}
+	privilege, err := NewPrivilegeFromSQLString(s)
+	if err != nil {
+		return &ErrUnknownPrivilege{Value: s}
+	}
+	*p = privilege
+	return nil
+}
+
+// ErrUnknownPrivilege is an error returned when a value isn't a known privilege.
+type ErrUnknownPrivilege struct {
+	Value string
+}
+
+func (e *ErrUnknownPrivilege) Error() string {
+	return fmt.Sprintf("unknown privilege %s", e.Value)
+}
+
 // Operation represents the kind of operation that can by executed in Tableland.
 type Operation int
 
@@ -111,6 +155,49 @@ func (op Operation) String() string {
 	return ""
 }
 
+// NewOperationFromString converts the string representation of an operation into an Operation.
+func NewOperationFromString(s string) (Operation, error) {
+	for op := OpSelect; op <= OpCreate; op++ {
+		if op.String() == s {
+			return op, nil
+		}
+	}
+
+	return 0, &ErrUnknownOperation{Value: s}
+}
+
+// MarshalJSON returns the JSON encoding of the operation as its string representation.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	s := op.String()
+	if s == "" {
+		return nil, &ErrUnknownOperation{Value: strconv.Itoa(int(op))}
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON parses an operation from its JSON encoded string representation.
+func (op *Operation) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &ErrUnknownOperation{Value: string(data)}
+	}
+	parsed, err := NewOperationFromString(s)
+	if err != nil {
+		return err
+	}
+	*op = parsed
+	return nil
+}
+
+// ErrUnknownOperation is an error returned when a value isn't a known operation.
+type ErrUnknownOperation struct {
+	Value string
+}
+
+func (e *ErrUnknownOperation) Error() string {
+	return fmt.Sprintf("unknown operation %s", e.Value)
+}
+
 var operationPrivilegeMap map[Operation]Privilege
 
 func init() {
@@ -120,6 +207,7 @@ func init() {
 		OpInsert: PrivInsert,
 		OpDelete: PrivDelete,
 		OpUpdate: PrivUpdate,
+		OpCreate: PrivCreate,
 	}
 }
 
@@ -142,6 +230,30 @@ func (p Privileges) CanExecute(operation Operation) (bool, Privilege) {
 	return false, privilegeNeededForOperation
 }
 
+// Diff returns the privileges that have to be granted and revoked to go
+// from the current list of privileges to the target one.
+func (p Privileges) Diff(target Privileges) (toGrant, toRevoke Privileges) {
+	return target.without(p), p.without(target)
+}
+
+// without returns the privileges of p that aren't in other.
+func (p Privileges) without(other Privileges) Privileges {
+	var res Privileges
+	for _, privilege := range p {
+		found := false
+		for _, o := range other {
+			if privilege == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, privilege)
+		}
+	}
+	return res
+}
+
 // Policy represents the kinds of restrictions that can be imposed on a statement execution.
 type Policy interface {
 	// IsInsertAllowed rejects insert statement execution.
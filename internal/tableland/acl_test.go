@@ -0,0 +1,121 @@
+package tableland
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivilegesDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		current     Privileges
+		target      Privileges
+		expToGrant  Privileges
+		expToRevoke Privileges
+	}{
+		{
+			name:        "overlapping",
+			current:     Privileges{PrivInsert, PrivUpdate},
+			target:      Privileges{PrivUpdate, PrivDelete},
+			expToGrant:  Privileges{PrivDelete},
+			expToRevoke: Privileges{PrivInsert},
+		},
+		{
+			name:        "disjoint",
+			current:     Privileges{PrivInsert},
+			target:      Privileges{PrivUpdate, PrivDelete},
+			expToGrant:  Privileges{PrivUpdate, PrivDelete},
+			expToRevoke: Privileges{PrivInsert},
+		},
+		{
+			name:    "identical",
+			current: Privileges{PrivInsert, PrivDelete},
+			target:  Privileges{PrivDelete, PrivInsert},
+		},
+		{
+			name:       "empty current",
+			target:     Privileges{PrivInsert},
+			expToGrant: Privileges{PrivInsert},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			toGrant, toRevoke := tc.current.Diff(tc.target)
+			require.Equal(t, tc.expToGrant, toGrant)
+			require.Equal(t, tc.expToRevoke, toRevoke)
+		})
+	}
+}
+
+func TestPrivilegeJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		privilege Privilege
+		expJSON   string
+	}{
+		{PrivInsert, `"insert"`},
+		{PrivUpdate, `"update"`},
+		{PrivDelete, `"delete"`},
+		{PrivCreate, `"create"`},
+	} {
+		b, err := json.Marshal(tc.privilege)
+		require.NoError(t, err)
+		require.JSONEq(t, tc.expJSON, string(b))
+
+		var privilege Privilege
+		require.NoError(t, json.Unmarshal(b, &privilege))
+		require.Equal(t, tc.privilege, privilege)
+	}
+
+	b, err := json.Marshal(Privileges{PrivInsert, PrivDelete})
+	require.NoError(t, err)
+	require.JSONEq(t, `["insert","delete"]`, string(b))
+
+	var errUnknown *ErrUnknownPrivilege
+	_, err = json.Marshal(Privilege{Abbreviation: "x", Bitfield: 0b10000})
+	require.ErrorAs(t, err, &errUnknown)
+	var privilege Privilege
+	require.ErrorAs(t, json.Unmarshal([]byte(`"truncate"`), &privilege), &errUnknown)
+	require.ErrorAs(t, json.Unmarshal([]byte(`42`), &privilege), &errUnknown)
+}
+
+func TestOperationJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		op      Operation
+		expJSON string
+	}{
+		{OpSelect, `"OpSelect"`},
+		{OpInsert, `"OpInsert"`},
+		{OpUpdate, `"OpUpdate"`},
+		{OpDelete, `"OpDelete"`},
+		{OpGrant, `"OpGrant"`},
+		{OpRevoke, `"OpRevoke"`},
+		{OpCreate, `"OpCreate"`},
+	} {
+		b, err := json.Marshal(tc.op)
+		require.NoError(t, err)
+		require.JSONEq(t, tc.expJSON, string(b))
+
+		var op Operation
+		require.NoError(t, json.Unmarshal(b, &op))
+		require.Equal(t, tc.op, op)
+	}
+
+	var errUnknown *ErrUnknownOperation
+	_, err := json.Marshal(Operation(42))
+	require.ErrorAs(t, err, &errUnknown)
+	var op Operation
+	require.ErrorAs(t, json.Unmarshal([]byte(`"OpTruncate"`), &op), &errUnknown)
+	require.ErrorAs(t, json.Unmarshal([]byte(`1`), &op), &errUnknown)
+}
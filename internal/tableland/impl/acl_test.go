@@ -0,0 +1,120 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
+	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
+	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/sqlstore"
+	"github.com/textileio/go-tableland/pkg/sqlstore/impl/system"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
+	"github.com/textileio/go-tableland/tests"
+)
+
+func TestACLCheckPrivileges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, db, ex := newACLSetup(t)
+	acl := NewACL(store, nil)
+
+	tableID, err := tables.NewTableID("100")
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	grantee := common.HexToAddress("0xd43c59d5694ec111eb9e986c233200b14249558d")
+
+	executeEvents(ctx, t, ex, common.HexToHash("0xF1"),
+		&ethereum.ContractCreateTable{
+			Owner:     owner,
+			TableId:   tableID.ToBigInt(),
+			Statement: "create table foo_1337 (bar text)",
+		},
+	)
+
+	requirePrivileges := func(expInsert, expUpdate, expDelete bool) {
+		t.Helper()
+
+		tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, tx.Rollback()) }()
+
+		for op, exp := range map[tableland.Operation]bool{
+			tableland.OpInsert: expInsert,
+			tableland.OpUpdate: expUpdate,
+			tableland.OpDelete: expDelete,
+		} {
+			ok, err := acl.CheckPrivileges(ctx, tx, grantee, tableID, op)
+			require.NoError(t, err)
+			require.Equal(t, exp, ok, "operation %s", op)
+		}
+	}
+
+	// No entry in the acl table yet.
+	requirePrivileges(false, false, false)
+
+	executeEvents(ctx, t, ex, common.HexToHash("0xF2"), &ethereum.ContractRunSQL{
+		IsOwner:   true,
+		Caller:    owner,
+		TableId:   tableID.ToBigInt(),
+		Statement: fmt.Sprintf("grant insert, update on foo_1337_100 to '%s'", grantee.Hex()),
+	})
+	requirePrivileges(true, true, false)
+
+	executeEvents(ctx, t, ex, common.HexToHash("0xF3"), &ethereum.ContractRunSQL{
+		IsOwner:   true,
+		Caller:    owner,
+		TableId:   tableID.ToBigInt(),
+		Statement: fmt.Sprintf("revoke insert on foo_1337_100 from '%s'", grantee.Hex()),
+	})
+	requirePrivileges(false, true, false)
+}
+
+func newACLSetup(t *testing.T) (sqlstore.SystemStore, *sql.DB, *executor.Executor) {
+	t.Helper()
+
+	dbURI := tests.Sqlite3URI(t)
+	store, err := system.New(dbURI, tableland.ChainID(1337))
+	require.NoError(t, err)
+
+	parser, err := parserimpl.New([]string{"system_", "registry", "sqlite_"})
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+
+	ex, err := executor.NewExecutor(1337, db, parser, 0, NewACL(store, nil))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ex.Close(context.Background())) })
+
+	return store, db, ex
+}
+
+func executeEvents(
+	ctx context.Context,
+	t *testing.T,
+	ex *executor.Executor,
+	txnHash common.Hash,
+	events ...interface{},
+) {
+	t.Helper()
+
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{
+		TxnHash: txnHash,
+		Events:  events,
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+}
@@ -2,11 +2,17 @@ package impl
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/internal/chains"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	"github.com/textileio/go-tableland/pkg/sqlstore"
 	"github.com/textileio/go-tableland/pkg/tables"
@@ -17,6 +23,78 @@ type TablelandMesa struct {
 	parser      parsing.SQLValidator
 	userStore   sqlstore.UserStore
 	chainStacks map[tableland.ChainID]chains.ChainStack
+	config      *Config
+}
+
+// Config contains configuration parameters for TablelandMesa.
+type Config struct {
+	// ReceiptWaitTimeout is the maximum amount of time RunReadQueryAfter waits for a receipt.
+	ReceiptWaitTimeout time.Duration
+	// ReceiptPollInterval is how long the first wait between receipt checks lasts. Each following
+	// wait doubles the previous one, up to ReceiptMaxPollInterval.
+	ReceiptPollInterval time.Duration
+	// ReceiptMaxPollInterval is the longest wait between receipt checks.
+	ReceiptMaxPollInterval time.Duration
+	// GateTableCreation indicates if relaying a table creation requires the caller to have the
+	// create privilege.
+	GateTableCreation bool
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		ReceiptWaitTimeout:     10 * time.Second,
+		ReceiptPollInterval:    250 * time.Millisecond,
+		ReceiptMaxPollInterval: 2 * time.Second,
+	}
+}
+
+// Option modifies a configuration attribute.
+type Option func(*Config) error
+
+// WithReceiptWaitTimeout limits the time RunReadQueryAfter waits for the receipt of a txn. If the
+// receipt doesn't exist before it expires, the read fails with *tableland.ErrReceiptWaitTimeout.
+func WithReceiptWaitTimeout(d time.Duration) Option {
+	return func(c *Config) error {
+		if d <= 0 {
+			return fmt.Errorf("receipt wait timeout must be greater than zero")
+		}
+		c.ReceiptWaitTimeout = d
+		return nil
+	}
+}
+
+// WithReceiptPollInterval sets how long to wait before checking again if the receipt of a txn exists
+// the first time it isn't found.
+func WithReceiptPollInterval(d time.Duration) Option {
+	return func(c *Config) error {
+		if d <= 0 {
+			return fmt.Errorf("receipt poll interval must be greater than zero")
+		}
+		c.ReceiptPollInterval = d
+		return nil
+	}
+}
+
+// WithReceiptMaxPollInterval limits how long the wait between receipt checks can grow.
+func WithReceiptMaxPollInterval(d time.Duration) Option {
+	return func(c *Config) error {
+		if d <= 0 {
+			return fmt.Errorf("receipt max poll interval must be greater than zero")
+		}
+		c.ReceiptMaxPollInterval = d
+		return nil
+	}
+}
+
+// WithTableCreationGating indicates if only callers with the create privilege in the ACL can relay
+// table creations. The gate only applies to RelayCreateTable, since tables created by calling the
+// registry contract directly are always executed. By default, anyone can create tables.
+func WithTableCreationGating(enabled bool) Option {
+	return func(c *Config) error {
+		c.GateTableCreation = enabled
+		return nil
+	}
 }
 
 // NewTablelandMesa creates a new TablelandMesa.
@@ -24,12 +102,21 @@ func NewTablelandMesa(
 	parser parsing.SQLValidator,
 	userStore sqlstore.UserStore,
 	chainStacks map[tableland.ChainID]chains.ChainStack,
-) tableland.Tableland {
+	opts ...Option,
+) (tableland.Tableland, error) {
+	config := DefaultConfig()
+	for _, o := range opts {
+		if err := o(config); err != nil {
+			return nil, fmt.Errorf("applying option: %s", err)
+		}
+	}
+
 	return &TablelandMesa{
 		parser:      parser,
 		userStore:   userStore,
 		chainStacks: chainStacks,
-	}
+		config:      config,
+	}, nil
 }
 
 // ValidateCreateTable allows to validate a CREATE TABLE statement and also return the structure hash of it.
@@ -39,9 +126,12 @@ func (t *TablelandMesa) ValidateCreateTable(
 	chainID tableland.ChainID,
 	statement string,
 ) (string, error) {
+	if _, ok := t.chainStacks[chainID]; !ok {
+		return "", &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
 	createStmt, err := t.parser.ValidateCreateTable(statement, chainID)
 	if err != nil {
-		return "", fmt.Errorf("parsing create table statement: %s", err)
+		return "", fmt.Errorf("parsing create table statement: %w", err)
 	}
 	return createStmt.GetStructureHash(), nil
 }
@@ -54,12 +144,12 @@ func (t *TablelandMesa) ValidateWriteQuery(
 ) (tables.TableID, error) {
 	stack, chainOk := t.chainStacks[chainID]
 	if !chainOk {
-		return tables.TableID{}, fmt.Errorf("chain id %d isn't supported in the validator", chainID)
+		return tables.TableID{}, &tableland.ErrUnsupportedChain{ChainID: chainID}
 	}
 
 	mutatingStmts, err := t.parser.ValidateMutatingQuery(statement, chainID)
 	if err != nil {
-		return tables.TableID{}, fmt.Errorf("validating query: %s", err)
+		return tables.TableID{}, fmt.Errorf("validating query: %w", err)
 	}
 
 	tableID := mutatingStmts[0].GetTableID()
@@ -71,7 +161,7 @@ func (t *TablelandMesa) ValidateWriteQuery(
 	}
 	// if the prefix is wrong the statement is not valid
 	prefix := mutatingStmts[0].GetPrefix()
-	if table.Prefix != prefix {
+	if !strings.EqualFold(table.Prefix, prefix) {
 		return tables.TableID{}, fmt.Errorf(
 			"table prefix doesn't match (exp %s, got %s)", table.Prefix, prefix)
 	}
@@ -88,7 +178,7 @@ func (t *TablelandMesa) RelayWriteQuery(
 ) (tables.Transaction, error) {
 	stack, ok := t.chainStacks[chainID]
 	if !ok {
-		return nil, fmt.Errorf("chain id %d isn't supported in the validator", chainID)
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
 	}
 
 	if !stack.AllowTransactionRelay {
@@ -98,7 +188,7 @@ func (t *TablelandMesa) RelayWriteQuery(
 
 	mutatingStmts, err := t.parser.ValidateMutatingQuery(statement, chainID)
 	if err != nil {
-		return nil, fmt.Errorf("validating query: %s", err)
+		return nil, fmt.Errorf("validating query: %w", err)
 	}
 
 	tableID := mutatingStmts[0].GetTableID()
@@ -110,20 +200,202 @@ func (t *TablelandMesa) RelayWriteQuery(
 	return tx, nil
 }
 
+// RelayCreateTable allows the user to rely on the validator wrapping a CREATE TABLE statement in a
+// chain transaction. If table creation is gated, the caller must have the create privilege.
+func (t *TablelandMesa) RelayCreateTable(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	caller common.Address,
+	statement string,
+) (tables.Transaction, error) {
+	stack, ok := t.chainStacks[chainID]
+	if !ok {
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
+
+	if !stack.AllowTransactionRelay {
+		return nil, fmt.Errorf("chain id %d does not suppport relaying of transactions", chainID)
+	}
+
+	if _, err := t.parser.ValidateCreateTable(statement, chainID); err != nil {
+		return nil, fmt.Errorf("parsing create table statement: %w", err)
+	}
+
+	if t.config.GateTableCreation {
+		privileges, err := stack.Store.GetCreatePrivilegesByController(ctx, caller.Hex())
+		if err != nil {
+			return nil, fmt.Errorf("getting create privileges: %s", err)
+		}
+		if isAllowed, _ := privileges.CanExecute(tableland.OpCreate); !isAllowed {
+			return nil, &tableland.ErrTableCreationDenied{Caller: caller}
+		}
+	}
+
+	tx, err := stack.Registry.CreateTable(ctx, caller, statement)
+	if err != nil {
+		return nil, fmt.Errorf("sending tx: %s", err)
+	}
+
+	return tx, nil
+}
+
+// ValidateRelayBatch validates a batch of write statements as RelayWriteQuery would relay it, and returns
+// the parsed statements without sending a transaction. Besides the statement validations, it checks that
+// the caller has the privileges the statements need and that the rows inserted by the batch don't exceed
+// the table row count limit of the chain. The checks use the current table state, so the batch can still
+// fail if the state changes before it's executed. Privileges granted by a controller contract policy
+// aren't considered.
+func (t *TablelandMesa) ValidateRelayBatch(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	caller common.Address,
+	statement string,
+) ([]parsing.WriteStmt, error) {
+	stack, ok := t.chainStacks[chainID]
+	if !ok {
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
+
+	// The parser already rejects batches that reference more than one table.
+	mutatingStmts, err := t.parser.ValidateMutatingQuery(statement, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("validating query: %w", err)
+	}
+	tableID := mutatingStmts[0].GetTableID()
+	table, err := stack.Store.GetTable(ctx, tableID)
+	if err != nil {
+		return nil, fmt.Errorf("getting table: %s", err)
+	}
+	if prefix := mutatingStmts[0].GetPrefix(); !strings.EqualFold(table.Prefix, prefix) {
+		return nil, fmt.Errorf("table prefix doesn't match (exp %s, got %s)", table.Prefix, prefix)
+	}
+
+	aclRule, err := stack.Store.GetACLOnTableByController(ctx, tableID, caller.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("privileges lookup: %s", err)
+	}
+	writeStmts := make([]parsing.WriteStmt, len(mutatingStmts))
+	insertedRows := 0
+	for i, stmt := range mutatingStmts {
+		ws, ok := stmt.(parsing.WriteStmt)
+		if !ok {
+			return nil, fmt.Errorf("only write statements can be validated as a batch")
+		}
+		if isAllowed, _ := aclRule.Privileges.CanExecute(ws.Operation()); !isAllowed {
+			return nil, &tableland.ErrPrivilegeDenied{Caller: caller, TableID: tableID, Operation: ws.Operation()}
+		}
+		insertedRows += ws.EstimateInsertedRows()
+		writeStmts[i] = ws
+	}
+
+	if stack.MaxTableRowCount > 0 && insertedRows > 0 {
+		rowCount, err := t.tableRowCount(ctx, mutatingStmts[0].GetDBTableName())
+		if err != nil {
+			return nil, fmt.Errorf("getting table row count: %s", err)
+		}
+		if rowCount+insertedRows > stack.MaxTableRowCount {
+			return nil, &tableland.ErrRowCountExceeded{
+				TableID: tableID,
+				Limit:   stack.MaxTableRowCount,
+				After:   rowCount + insertedRows,
+			}
+		}
+	}
+
+	return writeStmts, nil
+}
+
 // RunReadQuery allows the user to run SQL.
 func (t *TablelandMesa) RunReadQuery(ctx context.Context, statement string) (*tableland.TableData, error) {
 	readStmt, err := t.parser.ValidateReadQuery(statement)
 	if err != nil {
-		return nil, fmt.Errorf("validating query: %s", err)
+		return nil, fmt.Errorf("validating query: %w", err)
 	}
 
 	queryResult, err := t.runSelect(ctx, readStmt)
 	if err != nil {
-		return nil, fmt.Errorf("running read statement: %s", err)
+		return nil, fmt.Errorf("running read statement: %w", err)
 	}
 	return queryResult, nil
 }
 
+// RunReadQueryAfter allows the user to run SQL once the receipt of the txn with hash afterTxnHash
+// exists, so the result reflects the writes of that txn. It waits at most the configured timeout.
+func (t *TablelandMesa) RunReadQueryAfter(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	statement string,
+	afterTxnHash string,
+) (*tableland.TableData, error) {
+	if err := (&common.Hash{}).UnmarshalText([]byte(afterTxnHash)); err != nil {
+		return nil, fmt.Errorf("invalid txn hash: %s", err)
+	}
+	stack, ok := t.chainStacks[chainID]
+	if !ok {
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
+	if _, err := t.parser.ValidateReadQuery(statement); err != nil {
+		return nil, fmt.Errorf("validating query: %w", err)
+	}
+
+	if _, err := t.waitForReceipt(ctx, stack, afterTxnHash, t.config.ReceiptWaitTimeout); err != nil {
+		return nil, err
+	}
+
+	return t.RunReadQuery(ctx, statement)
+}
+
+// RunReadQueryPage allows the user to run SQL reading the results one page at a time.
+// An empty cursor returns the first page. The returned cursor must be provided to get the next page,
+// and it's empty if there are no more pages. The statement must have an ORDER BY clause so the
+// order of rows is stable between pages, otherwise it fails with *parsing.ErrNonDeterministicLimit.
+func (t *TablelandMesa) RunReadQueryPage(
+	ctx context.Context,
+	statement string,
+	pageSize int,
+	cursor string,
+) (*tableland.TableData, string, error) {
+	if pageSize <= 0 {
+		return nil, "", fmt.Errorf("page size must be greater than zero")
+	}
+	offset, err := decodePageCursor(cursor)
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding cursor: %s", err)
+	}
+
+	stmt, err := t.parser.ValidateReadQuery(statement)
+	if err != nil {
+		return nil, "", fmt.Errorf("validating query: %w", err)
+	}
+	if !stmt.IsOrdered() {
+		return nil, "", fmt.Errorf("validating query: %w", &parsing.ErrNonDeterministicLimit{})
+	}
+
+	// An extra row is fetched to know if there's a next page.
+	pageStatement := fmt.Sprintf(
+		"SELECT * FROM (%s) LIMIT %d OFFSET %d",
+		strings.TrimRight(strings.TrimSpace(statement), ";"),
+		pageSize+1,
+		offset,
+	)
+	readStmt, err := t.parser.ValidateReadQuery(pageStatement)
+	if err != nil {
+		return nil, "", fmt.Errorf("validating page query: %w", err)
+	}
+
+	queryResult, err := t.runSelect(ctx, readStmt)
+	if err != nil {
+		return nil, "", fmt.Errorf("running read statement: %w", err)
+	}
+
+	var nextCursor string
+	if len(queryResult.Rows) > pageSize {
+		queryResult.Rows = queryResult.Rows[:pageSize]
+		nextCursor = encodePageCursor(offset + pageSize)
+	}
+	return queryResult, nextCursor, nil
+}
+
 // GetReceipt returns the receipt of a processed event by txn hash.
 func (t *TablelandMesa) GetReceipt(
 	ctx context.Context,
@@ -135,7 +407,7 @@ func (t *TablelandMesa) GetReceipt(
 	}
 	stack, ok := t.chainStacks[chainID]
 	if !ok {
-		return false, nil, fmt.Errorf("chain id %d isn't supported in the validator", chainID)
+		return false, nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
 	}
 	receipt, ok, err := stack.Store.GetReceipt(ctx, txnHash)
 	if err != nil {
@@ -145,29 +417,169 @@ func (t *TablelandMesa) GetReceipt(
 		return false, nil, nil
 	}
 
-	errorEventIdx := -1
-	if receipt.ErrorEventIdx != nil {
-		errorEventIdx = *receipt.ErrorEventIdx
+	return ok, toTxnReceipt(receipt), nil
+}
+
+// WaitForReceipt waits until the receipt of a txn exists and returns it. The receipt is checked
+// with exponential backoff. If it doesn't exist after timeout, it fails with *tableland.ErrReceiptWaitTimeout.
+// A non-positive timeout uses the configured receipt wait timeout.
+func (t *TablelandMesa) WaitForReceipt(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	txnHash string,
+	timeout time.Duration,
+) (*tableland.TxnReceipt, error) {
+	if err := (&common.Hash{}).UnmarshalText([]byte(txnHash)); err != nil {
+		return nil, fmt.Errorf("invalid txn hash: %s", err)
 	}
-	errorMsg := ""
-	if receipt.Error != nil {
-		errorMsg = *receipt.Error
+	stack, ok := t.chainStacks[chainID]
+	if !ok {
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
+	if timeout <= 0 {
+		timeout = t.config.ReceiptWaitTimeout
 	}
 
-	ret := &tableland.TxnReceipt{
-		ChainID:       receipt.ChainID,
-		TxnHash:       receipt.TxnHash,
-		BlockNumber:   receipt.BlockNumber,
-		Error:         errorMsg,
-		ErrorEventIdx: errorEventIdx,
+	receipt, err := t.waitForReceipt(ctx, stack, txnHash, timeout)
+	if err != nil {
+		return nil, err
 	}
+	return toTxnReceipt(receipt), nil
+}
 
-	if receipt.TableID != nil {
-		tID := receipt.TableID.String()
-		ret.TableID = &tID
+// GetTableColumns returns the columns of a table as they're defined in its CREATE statement, in
+// declaration order.
+func (t *TablelandMesa) GetTableColumns(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	tableID tables.TableID,
+) ([]parsing.ColumnDefinition, error) {
+	stack, ok := t.chainStacks[chainID]
+	if !ok {
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
+	table, err := stack.Store.GetTable(ctx, tableID)
+	if err != nil {
+		return nil, fmt.Errorf("getting table: %w", err)
+	}
+	schema, err := stack.Store.GetSchemaByTableName(ctx, table.Name())
+	if err != nil {
+		return nil, fmt.Errorf("getting table schema: %s", err)
+	}
+
+	accepted := map[string]struct{}{}
+	for _, name := range parsing.GetAcceptedTypeNames() {
+		accepted[name] = struct{}{}
+	}
+	columns := make([]parsing.ColumnDefinition, len(schema.Columns))
+	for i, col := range schema.Columns {
+		if _, ok := accepted[col.Type]; !ok {
+			return nil, fmt.Errorf("column %s has unknown type %s", col.Name, col.Type)
+		}
+		columns[i] = parsing.ColumnDefinition{Name: col.Name, Type: col.Type, NotNull: col.NotNull}
+	}
+	return columns, nil
+}
+
+// GetTableJSONSchema returns a JSON Schema document describing a row of a table, as an object
+// with one property per column. Columns with a NOT NULL constraint are required and don't accept null.
+func (t *TablelandMesa) GetTableJSONSchema(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	tableID tables.TableID,
+) ([]byte, error) {
+	columns, err := t.GetTableColumns(ctx, chainID, tableID)
+	if err != nil {
+		return nil, fmt.Errorf("getting table columns: %w", err)
+	}
+	b, err := tableJSONSchema(columns)
+	if err != nil {
+		return nil, fmt.Errorf("building json schema: %s", err)
+	}
+	return b, nil
+}
+
+// jsonSchemaProperty is the JSON Schema of a column value.
+// Type is a single type name, or a list of names if the value can also be null.
+type jsonSchemaProperty struct {
+	Type            interface{} `json:"type"`
+	ContentEncoding string      `json:"contentEncoding,omitempty"`
+}
+
+// tableJSONSchema builds the JSON Schema of a row with the provided columns. Values are typed as
+// they're returned by reads, so blobs are base64 encoded strings.
+func tableJSONSchema(columns []parsing.ColumnDefinition) ([]byte, error) {
+	categories := map[string]parsing.TypeCategory{}
+	for _, at := range parsing.GetAcceptedTypes() {
+		categories[at.Name] = at.Category
+	}
+
+	properties := make(map[string]jsonSchemaProperty, len(columns))
+	required := []string{}
+	for _, col := range columns {
+		var prop jsonSchemaProperty
+		var typ string
+		switch categories[col.Type] {
+		case parsing.TypeCategoryNumeric:
+			typ = "integer"
+		case parsing.TypeCategoryText:
+			typ = "string"
+		case parsing.TypeCategoryBinary:
+			typ = "string"
+			prop.ContentEncoding = "base64"
+		default:
+			return nil, fmt.Errorf("column %s has unknown type %s", col.Name, col.Type)
+		}
+		if col.NotNull {
+			prop.Type = typ
+			required = append(required, col.Name)
+		} else {
+			prop.Type = []string{typ, "null"}
+		}
+		properties[col.Name] = prop
+	}
+
+	return json.Marshal(struct {
+		Schema               string                        `json:"$schema"`
+		Type                 string                        `json:"type"`
+		Properties           map[string]jsonSchemaProperty `json:"properties"`
+		Required             []string                      `json:"required"`
+		AdditionalProperties bool                          `json:"additionalProperties"`
+	}{
+		Schema:               "https://json-schema.org/draft/2020-12/schema",
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: false,
+	})
+}
+
+// GetReceipts returns the receipts of processed events by txn hashes, keyed by txn hash.
+// Hashes that don't have a receipt yet aren't present in the returned map.
+func (t *TablelandMesa) GetReceipts(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	txnHashes []string,
+) (map[string]*tableland.TxnReceipt, error) {
+	for _, txnHash := range txnHashes {
+		if err := (&common.Hash{}).UnmarshalText([]byte(txnHash)); err != nil {
+			return nil, fmt.Errorf("invalid txn hash %s: %s", txnHash, err)
+		}
+	}
+	stack, ok := t.chainStacks[chainID]
+	if !ok {
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
+	}
+	receipts, err := stack.Store.GetReceipts(ctx, txnHashes)
+	if err != nil {
+		return nil, fmt.Errorf("get txn receipts: %s", err)
 	}
 
-	return ok, ret, nil
+	ret := make(map[string]*tableland.TxnReceipt, len(receipts))
+	for _, receipt := range receipts {
+		ret[receipt.TxnHash] = toTxnReceipt(receipt)
+	}
+	return ret, nil
 }
 
 // SetController allows users to the controller for a token id.
@@ -180,7 +592,7 @@ func (t *TablelandMesa) SetController(
 ) (tables.Transaction, error) {
 	stack, ok := t.chainStacks[chainID]
 	if !ok {
-		return nil, fmt.Errorf("chain id %d isn't supported in the validator", chainID)
+		return nil, &tableland.ErrUnsupportedChain{ChainID: chainID}
 	}
 
 	if !stack.AllowTransactionRelay {
@@ -195,14 +607,115 @@ func (t *TablelandMesa) SetController(
 	return tx, nil
 }
 
+func (t *TablelandMesa) waitForReceipt(
+	ctx context.Context,
+	stack chains.ChainStack,
+	txnHash string,
+	timeout time.Duration,
+) (eventprocessor.Receipt, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	pollInterval := t.config.ReceiptPollInterval
+	poll := time.NewTimer(pollInterval)
+	defer poll.Stop()
+	for {
+		receipt, ok, err := stack.Store.GetReceipt(ctx, txnHash)
+		if err != nil {
+			return eventprocessor.Receipt{}, fmt.Errorf("get txn receipt: %s", err)
+		}
+		if ok {
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return eventprocessor.Receipt{}, fmt.Errorf("waiting for txn receipt: %w", ctx.Err())
+		case <-timer.C:
+			return eventprocessor.Receipt{}, &tableland.ErrReceiptWaitTimeout{TxnHash: txnHash, Timeout: timeout}
+		case <-poll.C:
+		}
+
+		pollInterval *= 2
+		if pollInterval > t.config.ReceiptMaxPollInterval {
+			pollInterval = t.config.ReceiptMaxPollInterval
+		}
+		poll.Reset(pollInterval)
+	}
+}
+
 func (t *TablelandMesa) runSelect(
 	ctx context.Context,
 	stmt parsing.ReadStmt,
 ) (*tableland.TableData, error) {
 	queryResult, err := t.userStore.Read(ctx, stmt)
 	if err != nil {
-		return nil, fmt.Errorf("executing read-query: %s", err)
+		return nil, fmt.Errorf("executing read-query: %w", err)
 	}
 
 	return queryResult, nil
 }
+
+func (t *TablelandMesa) tableRowCount(ctx context.Context, dbTableName string) (int, error) {
+	readStmt, err := t.parser.ValidateReadQuery(fmt.Sprintf("SELECT count(*) FROM %s", dbTableName))
+	if err != nil {
+		return 0, fmt.Errorf("validating count query: %s", err)
+	}
+	data, err := t.userStore.Read(ctx, readStmt)
+	if err != nil {
+		return 0, fmt.Errorf("executing count query: %s", err)
+	}
+	if len(data.Rows) != 1 || len(data.Rows[0]) != 1 {
+		return 0, fmt.Errorf("unexpected count query result")
+	}
+	count, ok := data.Rows[0][0].Value().(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count type %T", data.Rows[0][0].Value())
+	}
+	return int(count), nil
+}
+
+func encodePageCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor encoding: %s", err)
+	}
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return offset, nil
+}
+
+func toTxnReceipt(receipt eventprocessor.Receipt) *tableland.TxnReceipt {
+	errorEventIdx := -1
+	if receipt.ErrorEventIdx != nil {
+		errorEventIdx = *receipt.ErrorEventIdx
+	}
+	errorMsg := ""
+	if receipt.Error != nil {
+		errorMsg = *receipt.Error
+	}
+
+	ret := &tableland.TxnReceipt{
+		ChainID:       receipt.ChainID,
+		TxnHash:       receipt.TxnHash,
+		BlockNumber:   receipt.BlockNumber,
+		Error:         errorMsg,
+		ErrorEventIdx: errorEventIdx,
+		ErrorCode:     receipt.ErrorCode,
+	}
+
+	if receipt.TableID != nil {
+		tID := receipt.TableID.String()
+		ret.TableID = &tID
+	}
+	return ret
+}
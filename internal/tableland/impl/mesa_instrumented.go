@@ -81,6 +81,36 @@ func (t *InstrumentedTablelandMesa) RunReadQuery(ctx context.Context, stmt strin
 	return resp, err
 }
 
+// RunReadQueryAfter allows the user to run SQL once the receipt of a given txn exists.
+func (t *InstrumentedTablelandMesa) RunReadQueryAfter(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	stmt string,
+	afterTxnHash string,
+) (*tableland.TableData, error) {
+	start := time.Now()
+	resp, err := t.tableland.RunReadQueryAfter(ctx, chainID, stmt, afterTxnHash)
+	latency := time.Since(start).Milliseconds()
+
+	t.record(ctx, recordData{"RunReadQueryAfter", "", "", err == nil, latency, chainID})
+	return resp, err
+}
+
+// RunReadQueryPage allows the user to run SQL reading the results one page at a time.
+func (t *InstrumentedTablelandMesa) RunReadQueryPage(
+	ctx context.Context,
+	stmt string,
+	pageSize int,
+	cursor string,
+) (*tableland.TableData, string, error) {
+	start := time.Now()
+	resp, nextCursor, err := t.tableland.RunReadQueryPage(ctx, stmt, pageSize, cursor)
+	latency := time.Since(start).Milliseconds()
+
+	t.record(ctx, recordData{"RunReadQueryPage", "", "", err == nil, latency, 0})
+	return resp, nextCursor, err
+}
+
 // RelayWriteQuery allows the user to rely on the validator to wrap a write-query in a chain transaction.
 func (t *InstrumentedTablelandMesa) RelayWriteQuery(
 	ctx context.Context,
@@ -96,6 +126,21 @@ func (t *InstrumentedTablelandMesa) RelayWriteQuery(
 	return resp, err
 }
 
+// RelayCreateTable allows the user to rely on the validator to wrap a CREATE TABLE statement in a chain transaction.
+func (t *InstrumentedTablelandMesa) RelayCreateTable(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	caller common.Address,
+	stmt string,
+) (tables.Transaction, error) {
+	start := time.Now()
+	resp, err := t.tableland.RelayCreateTable(ctx, chainID, caller, stmt)
+	latency := time.Since(start).Milliseconds()
+
+	t.record(ctx, recordData{"RelayCreateTable", caller.Hex(), "", err == nil, latency, chainID})
+	return resp, err
+}
+
 // GetReceipt returns the receipt for a txn hash.
 func (t *InstrumentedTablelandMesa) GetReceipt(
 	ctx context.Context,
@@ -110,6 +155,49 @@ func (t *InstrumentedTablelandMesa) GetReceipt(
 	return ok, resp, err
 }
 
+// GetTableJSONSchema returns the JSON Schema of a table's rows.
+func (t *InstrumentedTablelandMesa) GetTableJSONSchema(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	tableID tables.TableID,
+) ([]byte, error) {
+	start := time.Now()
+	resp, err := t.tableland.GetTableJSONSchema(ctx, chainID, tableID)
+	latency := time.Since(start).Milliseconds()
+
+	t.record(ctx, recordData{"GetTableJSONSchema", "", "", err == nil, latency, chainID})
+	return resp, err
+}
+
+// GetReceipts returns the receipts for multiple txn hashes.
+func (t *InstrumentedTablelandMesa) GetReceipts(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	txnHashes []string,
+) (map[string]*tableland.TxnReceipt, error) {
+	start := time.Now()
+	resp, err := t.tableland.GetReceipts(ctx, chainID, txnHashes)
+	latency := time.Since(start).Milliseconds()
+
+	t.record(ctx, recordData{"GetReceipts", "", "", err == nil, latency, chainID})
+	return resp, err
+}
+
+// WaitForReceipt waits for the receipt of a txn hash.
+func (t *InstrumentedTablelandMesa) WaitForReceipt(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	txnHash string,
+	timeout time.Duration,
+) (*tableland.TxnReceipt, error) {
+	start := time.Now()
+	resp, err := t.tableland.WaitForReceipt(ctx, chainID, txnHash, timeout)
+	latency := time.Since(start).Milliseconds()
+
+	t.record(ctx, recordData{"WaitForReceipt", "", "", err == nil, latency, chainID})
+	return resp, err
+}
+
 // SetController allows users to the controller for a token id.
 func (t *InstrumentedTablelandMesa) SetController(
 	ctx context.Context,
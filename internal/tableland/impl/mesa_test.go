@@ -186,6 +186,419 @@ func TestJSON(t *testing.T) {
 	processCSV(ctx, t, chainID, caller, tbld, "testdata/json_queries.csv", backend)
 }
 
+func TestRunReadQueryPage(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withAllowTransactionRelay(true).
+		build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (id INTEGER);`)
+	require.NoError(t, err)
+
+	values := make([]string, 25)
+	for i := range values {
+		values[i] = fmt.Sprintf("(%d)", i+1)
+	}
+	_, err = tbld.RelayWriteQuery(ctx, chainID, caller, "INSERT INTO foo_1337_1 VALUES "+strings.Join(values, ","))
+	require.NoError(t, err)
+	backend.Commit()
+
+	require.Eventually(
+		t,
+		runSQLCountEq(ctx, t, tbld, "SELECT * FROM foo_1337_1", 25),
+		5*time.Second,
+		100*time.Millisecond,
+	)
+
+	t.Run("pages", func(t *testing.T) {
+		var ids []int64
+		var pageSizes []int
+		cursor := ""
+		for {
+			page, next, err := tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1 ORDER BY id;", 10, cursor)
+			require.NoError(t, err)
+			pageSizes = append(pageSizes, len(page.Rows))
+			for _, row := range page.Rows {
+				ids = append(ids, row[0].Value().(int64))
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		require.Equal(t, []int{10, 10, 5}, pageSizes)
+		require.Len(t, ids, 25)
+		for i, id := range ids {
+			require.Equal(t, int64(i+1), id)
+		}
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		_, _, err := tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1 ORDER BY id", 0, "")
+		require.Error(t, err)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, _, err := tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1 ORDER BY id", 10, "not-a-cursor")
+		require.Error(t, err)
+	})
+
+	t.Run("without order by", func(t *testing.T) {
+		_, _, err := tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1", 10, "")
+		var errNonDeterministicLimit *parsing.ErrNonDeterministicLimit
+		require.ErrorAs(t, err, &errNonDeterministicLimit)
+	})
+}
+
+func TestRunReadQueryPageRequireOrderBy(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withAllowTransactionRelay(true).
+		withParsingOpts(parsing.WithRequireOrderByWithLimit(true)).
+		build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (id INTEGER);`)
+	require.NoError(t, err)
+	_, err = tbld.RelayWriteQuery(ctx, chainID, caller, "INSERT INTO foo_1337_1 VALUES (1),(2),(3)")
+	require.NoError(t, err)
+	backend.Commit()
+
+	require.Eventually(
+		t,
+		runSQLCountEq(ctx, t, tbld, "SELECT * FROM foo_1337_1", 3),
+		5*time.Second,
+		100*time.Millisecond,
+	)
+
+	page, next, err := tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1 ORDER BY id", 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Rows, 2)
+	require.NotEmpty(t, next)
+
+	page, next, err = tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1 ORDER BY id", 2, next)
+	require.NoError(t, err)
+	require.Len(t, page.Rows, 1)
+	require.Equal(t, int64(3), page.Rows[0][0].Value().(int64))
+	require.Empty(t, next)
+
+	// Pages of unordered rows aren't deterministic.
+	_, _, err = tbld.RunReadQueryPage(ctx, "SELECT id FROM foo_1337_1", 2, "")
+	var errNonDeterministicLimit *parsing.ErrNonDeterministicLimit
+	require.ErrorAs(t, err, &errNonDeterministicLimit)
+}
+
+func TestRunReadQueryAfter(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withAllowTransactionRelay(true).
+		withMesaOpts(WithReceiptWaitTimeout(2*time.Second), WithReceiptPollInterval(10*time.Millisecond)).
+		build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (name TEXT);`)
+	require.NoError(t, err)
+	txn, err := tbld.RelayWriteQuery(ctx, chainID, caller, `INSERT INTO foo_1337_1 VALUES ('bar')`)
+	require.NoError(t, err)
+
+	t.Run("waits for receipt", func(t *testing.T) {
+		type result struct {
+			data *tableland.TableData
+			err  error
+		}
+		resCh := make(chan result, 1)
+		go func() {
+			data, err := tbld.RunReadQueryAfter(ctx, chainID, "SELECT name FROM foo_1337_1", txn.Hash().Hex())
+			resCh <- result{data, err}
+		}()
+
+		// The txn isn't mined yet, so the read must still be waiting.
+		select {
+		case <-resCh:
+			t.Fatal("read returned before the txn receipt existed")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		backend.Commit()
+
+		res := <-resCh
+		require.NoError(t, res.err)
+		require.Len(t, res.data.Rows, 1)
+		require.Equal(t, "bar", res.data.Rows[0][0].Value())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		unknownHash := common.HexToHash("0xdeadbeef").Hex()
+		_, err := tbld.RunReadQueryAfter(ctx, chainID, "SELECT name FROM foo_1337_1", unknownHash)
+		var errTimeout *tableland.ErrReceiptWaitTimeout
+		require.ErrorAs(t, err, &errTimeout)
+		require.Equal(t, unknownHash, errTimeout.TxnHash)
+	})
+
+	t.Run("invalid txn hash", func(t *testing.T) {
+		_, err := tbld.RunReadQueryAfter(ctx, chainID, "SELECT name FROM foo_1337_1", "invalid")
+		require.Error(t, err)
+	})
+}
+
+func TestWaitForReceipt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chainID := tableland.ChainID(1337)
+	txnHash := common.HexToHash("0xdeadbeef").Hex()
+
+	newTableland := func(t *testing.T, store *receiptPollingStore) tableland.Tableland {
+		t.Helper()
+		tbld, err := NewTablelandMesa(
+			nil,
+			nil,
+			map[tableland.ChainID]chains.ChainStack{chainID: {Store: store}},
+			WithReceiptPollInterval(time.Millisecond),
+		)
+		require.NoError(t, err)
+		return tbld
+	}
+
+	t.Run("receipt appears after two polls", func(t *testing.T) {
+		t.Parallel()
+
+		store := &receiptPollingStore{foundAfter: 2}
+		receipt, err := newTableland(t, store).WaitForReceipt(ctx, chainID, txnHash, 5*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 3, store.calls)
+		require.Equal(t, chainID, receipt.ChainID)
+		require.Equal(t, txnHash, receipt.TxnHash)
+		require.Equal(t, int64(100), receipt.BlockNumber)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		store := &receiptPollingStore{foundAfter: -1}
+		_, err := newTableland(t, store).WaitForReceipt(ctx, chainID, txnHash, 50*time.Millisecond)
+		var errTimeout *tableland.ErrReceiptWaitTimeout
+		require.ErrorAs(t, err, &errTimeout)
+		require.Equal(t, txnHash, errTimeout.TxnHash)
+		require.Equal(t, 50*time.Millisecond, errTimeout.Timeout)
+	})
+}
+
+func TestReceiptErrorCode(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withAllowTransactionRelay(true).
+		withMaxTableRowCount(1).
+		build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (name TEXT);`)
+	require.NoError(t, err)
+	txn, err := tbld.RelayWriteQuery(ctx, chainID, caller, `INSERT INTO foo_1337_1 VALUES ('bar'), ('baz')`)
+	require.NoError(t, err)
+	backend.Commit()
+
+	receipt, err := tbld.WaitForReceipt(ctx, chainID, txn.Hash().Hex(), 5*time.Second)
+	require.NoError(t, err)
+	require.Contains(t, receipt.Error, "table maximum row count exceeded")
+	require.Equal(t, eventprocessor.ErrorCodeRowCountExceeded, receipt.ErrorCode)
+}
+
+func TestValidateRelayBatch(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withMaxTableRowCount(2).
+		build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland.(*TablelandMesa), tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (name TEXT);`)
+	require.NoError(t, err)
+	backend.Commit()
+	require.Eventually(t, func() bool {
+		_, err := tbld.ValidateWriteQuery(ctx, chainID, "DELETE FROM foo_1337_1 WHERE name = 'bar'")
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	t.Run("valid batch", func(t *testing.T) {
+		stmts, err := tbld.ValidateRelayBatch(ctx, chainID, caller,
+			`INSERT INTO foo_1337_1 VALUES ('bar'), ('baz'); UPDATE foo_1337_1 SET name = 'qux' WHERE name = 'bar'`)
+		require.NoError(t, err)
+		require.Len(t, stmts, 2)
+		require.Equal(t, tableland.OpInsert, stmts[0].Operation())
+		require.Equal(t, tableland.OpUpdate, stmts[1].Operation())
+	})
+
+	t.Run("exceeds row count limit", func(t *testing.T) {
+		_, err := tbld.ValidateRelayBatch(ctx, chainID, caller,
+			`INSERT INTO foo_1337_1 VALUES ('bar'), ('baz'); INSERT INTO foo_1337_1 VALUES ('qux')`)
+		var errRowCount *tableland.ErrRowCountExceeded
+		require.ErrorAs(t, err, &errRowCount)
+		require.Equal(t, 2, errRowCount.Limit)
+		require.Equal(t, 3, errRowCount.After)
+	})
+
+	t.Run("caller without privileges", func(t *testing.T) {
+		stranger := common.HexToAddress("0xd43c59d5694ec111eb9e986c233200b14249558d")
+		_, err := tbld.ValidateRelayBatch(ctx, chainID, stranger, `INSERT INTO foo_1337_1 VALUES ('bar')`)
+		var errDenied *tableland.ErrPrivilegeDenied
+		require.ErrorAs(t, err, &errDenied)
+		require.Equal(t, tableland.OpInsert, errDenied.Operation)
+	})
+}
+
+func TestGetReceipts(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withAllowTransactionRelay(true).
+		build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (name TEXT);`)
+	require.NoError(t, err)
+
+	r1, err := tbld.RelayWriteQuery(ctx, chainID, caller, `INSERT INTO foo_1337_1 VALUES ('bar')`)
+	require.NoError(t, err)
+	r2, err := tbld.RelayWriteQuery(ctx, chainID, caller, `INSERT INTO foo_1337_1 VALUES ('baz')`)
+	require.NoError(t, err)
+	backend.Commit()
+
+	require.Eventually(
+		t,
+		runSQLCountEq(ctx, t, tbld, "SELECT * FROM foo_1337_1", 2),
+		5*time.Second,
+		100*time.Millisecond,
+	)
+
+	unknownHash := common.HexToHash("0xdeadbeef").Hex()
+	knownHashes := []string{r1.Hash().Hex(), r2.Hash().Hex()}
+	receipts, err := tbld.GetReceipts(ctx, chainID, append(knownHashes, unknownHash))
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	require.NotContains(t, receipts, unknownHash)
+	for _, txnHash := range knownHashes {
+		require.Contains(t, receipts, txnHash)
+		receipt := receipts[txnHash]
+		require.Equal(t, chainID, receipt.ChainID)
+		require.Equal(t, txnHash, receipt.TxnHash)
+		require.NotZero(t, receipt.BlockNumber)
+		require.Empty(t, receipt.Error)
+		require.NotNil(t, receipt.TableID)
+	}
+
+	receipts, err = tbld.GetReceipts(ctx, chainID, []string{unknownHash})
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+
+	_, err = tbld.GetReceipts(ctx, chainID, []string{"invalid"})
+	require.Error(t, err)
+}
+
+func TestGetTableColumns(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller,
+		`CREATE TABLE foo_1337 (id INTEGER PRIMARY KEY, name text NOT NULL, age int, photo BLOB);`)
+	require.NoError(t, err)
+	backend.Commit()
+
+	tableID, err := tables.NewTableID("1")
+	require.NoError(t, err)
+	mesa := tbld.(*TablelandMesa)
+	var columns []parsing.ColumnDefinition
+	require.Eventually(t, func() bool {
+		columns, err = mesa.GetTableColumns(ctx, chainID, tableID)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Equal(t, []parsing.ColumnDefinition{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "text", NotNull: true},
+		{Name: "age", Type: "int"},
+		{Name: "photo", Type: "blob"},
+	}, columns)
+
+	missingID, err := tables.NewTableID("2")
+	require.NoError(t, err)
+	_, err = mesa.GetTableColumns(ctx, chainID, missingID)
+	require.Error(t, err)
+
+	var unsupportedErr *tableland.ErrUnsupportedChain
+	_, err = mesa.GetTableColumns(ctx, 1, tableID)
+	require.ErrorAs(t, err, &unsupportedErr)
+}
+
+func TestGetTableJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().build(t)
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, backend, sc := setup.ctx, setup.chainID, setup.ethClient, setup.contract
+	tbld, txOpts := tablelandClient.tableland, tablelandClient.txOpts
+	caller := txOpts.From
+
+	_, err := sc.CreateTable(txOpts, caller,
+		`CREATE TABLE foo_1337 (id int NOT NULL, name text, photo blob);`)
+	require.NoError(t, err)
+	backend.Commit()
+
+	tableID, err := tables.NewTableID("1")
+	require.NoError(t, err)
+	var schema []byte
+	require.Eventually(t, func() bool {
+		schema, err = tbld.GetTableJSONSchema(ctx, chainID, tableID)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"name": {"type": ["string", "null"]},
+			"photo": {"type": ["string", "null"], "contentEncoding": "base64"}
+		},
+		"required": ["id"],
+		"additionalProperties": false
+	}`, string(schema))
+}
+
 func TestCheckInsertPrivileges(t *testing.T) {
 	t.Parallel()
 
@@ -666,6 +1079,154 @@ func TestAllowTransactionRelayConfig(t *testing.T) {
 	})
 }
 
+func TestUnsupportedChain(t *testing.T) {
+	t.Parallel()
+
+	setup := newTablelandSetupBuilder().
+		withAllowTransactionRelay(true).
+		build(t)
+
+	tablelandClient := setup.newTablelandClient(t)
+
+	ctx, chainID, tbld, txOpts := setup.ctx, setup.chainID, tablelandClient.tableland, tablelandClient.txOpts
+	unsupportedChainID := tableland.ChainID(1)
+
+	t.Run("validate create table", func(t *testing.T) {
+		_, err := tbld.ValidateCreateTable(ctx, unsupportedChainID, "CREATE TABLE foo_1 (bar INT)")
+		var errUnsupportedChain *tableland.ErrUnsupportedChain
+		require.ErrorAs(t, err, &errUnsupportedChain)
+		require.Equal(t, unsupportedChainID, errUnsupportedChain.ChainID)
+
+		hash, err := tbld.ValidateCreateTable(ctx, chainID, "CREATE TABLE foo_1337 (bar INT)")
+		require.NoError(t, err)
+		require.NotEmpty(t, hash)
+	})
+
+	t.Run("relay write query", func(t *testing.T) {
+		_, err := relayWriteQuery(ctx, t, unsupportedChainID, tbld, "INSERT INTO foo_1_1 VALUES ('bar')", txOpts.From)
+		var errUnsupportedChain *tableland.ErrUnsupportedChain
+		require.ErrorAs(t, err, &errUnsupportedChain)
+		require.Equal(t, unsupportedChainID, errUnsupportedChain.ChainID)
+	})
+}
+
+func TestMultipleChainsRouting(t *testing.T) {
+	t.Parallel()
+
+	parser, err := parserimpl.New([]string{"system_", "registry", "sqlite_"})
+	require.NoError(t, err)
+
+	registry1, registry2 := &registryRecorder{}, &registryRecorder{}
+	tbld, err := NewTablelandMesa(parser, nil, map[tableland.ChainID]chains.ChainStack{
+		1: {Registry: registry1, AllowTransactionRelay: true},
+		2: {Registry: registry2, AllowTransactionRelay: true},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	caller := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+
+	_, err = tbld.RelayWriteQuery(ctx, 1, caller, "INSERT INTO foo_1_1 VALUES (1)")
+	require.NoError(t, err)
+	_, err = tbld.RelayWriteQuery(ctx, 2, caller, "INSERT INTO foo_2_5 VALUES (1)")
+	require.NoError(t, err)
+	_, err = tbld.RelayWriteQuery(ctx, 2, caller, "INSERT INTO foo_2_6 VALUES (1)")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"1"}, registry1.tableIDs)
+	require.Equal(t, []string{"5", "6"}, registry2.tableIDs)
+
+	_, err = tbld.RelayWriteQuery(ctx, 3, caller, "INSERT INTO foo_3_1 VALUES (1)")
+	var errUnsupportedChain *tableland.ErrUnsupportedChain
+	require.ErrorAs(t, err, &errUnsupportedChain)
+	require.Equal(t, tableland.ChainID(3), errUnsupportedChain.ChainID)
+}
+
+func TestRelayMixedOps(t *testing.T) {
+	t.Parallel()
+
+	parser, err := parserimpl.NewGateway([]string{"system_", "registry", "sqlite_"}, nil, parsing.WithAllowMixedOps(false))
+	require.NoError(t, err)
+
+	registry := &registryRecorder{}
+	tbld, err := NewTablelandMesa(parser, nil, map[tableland.ChainID]chains.ChainStack{
+		1337: {Registry: registry, AllowTransactionRelay: true},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	caller := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+
+	_, err = tbld.RelayWriteQuery(ctx, 1337, caller, "INSERT INTO foo_1337_1 VALUES (1); INSERT INTO foo_1337_1 VALUES (2)")
+	require.NoError(t, err)
+
+	_, err = tbld.RelayWriteQuery(ctx, 1337, caller, "INSERT INTO foo_1337_1 VALUES (1); DELETE FROM foo_1337_1")
+	var errMixedOps *parsing.ErrMixedOperations
+	require.ErrorAs(t, err, &errMixedOps)
+
+	_, err = tbld.ValidateWriteQuery(ctx, 1337, "INSERT INTO foo_1337_1 VALUES (1); DELETE FROM foo_1337_1")
+	require.ErrorAs(t, err, &errMixedOps)
+
+	require.Equal(t, []string{"1"}, registry.tableIDs)
+}
+
+func TestRelayCreateTable(t *testing.T) {
+	t.Parallel()
+
+	parser, err := parserimpl.New([]string{"system_", "registry", "sqlite_"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	allowed := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	denied := common.HexToAddress("0xd43c59d5694ec111eb9e986c233200b14249558d")
+
+	t.Run("not gated", func(t *testing.T) {
+		registry := &registryRecorder{}
+		tbld, err := NewTablelandMesa(parser, nil, map[tableland.ChainID]chains.ChainStack{
+			1337: {Registry: registry, AllowTransactionRelay: true},
+		})
+		require.NoError(t, err)
+
+		_, err = tbld.RelayCreateTable(ctx, 1337, denied, "CREATE TABLE foo_1337 (bar text)")
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{denied}, registry.owners)
+	})
+
+	t.Run("gated", func(t *testing.T) {
+		store, err := system.New(tests.Sqlite3URI(t), 1337)
+		require.NoError(t, err)
+		require.NoError(t, store.GrantCreatePrivilege(ctx, allowed))
+
+		registry := &registryRecorder{}
+		tbld, err := NewTablelandMesa(parser, nil, map[tableland.ChainID]chains.ChainStack{
+			1337: {Store: store, Registry: registry, AllowTransactionRelay: true},
+		}, WithTableCreationGating(true))
+		require.NoError(t, err)
+
+		_, err = tbld.RelayCreateTable(ctx, 1337, allowed, "CREATE TABLE foo_1337 (bar text)")
+		require.NoError(t, err)
+
+		_, err = tbld.RelayCreateTable(ctx, 1337, denied, "CREATE TABLE foo_1337 (bar text)")
+		var errDenied *tableland.ErrTableCreationDenied
+		require.ErrorAs(t, err, &errDenied)
+		require.Equal(t, denied, errDenied.Caller)
+
+		require.Equal(t, []common.Address{allowed}, registry.owners)
+	})
+
+	t.Run("invalid statement", func(t *testing.T) {
+		registry := &registryRecorder{}
+		tbld, err := NewTablelandMesa(parser, nil, map[tableland.ChainID]chains.ChainStack{
+			1337: {Registry: registry, AllowTransactionRelay: true},
+		})
+		require.NoError(t, err)
+
+		_, err = tbld.RelayCreateTable(ctx, 1337, allowed, "CREATE TABLE foo_1 (bar text)")
+		require.Error(t, err)
+		require.Empty(t, registry.owners)
+	})
+}
+
 func processCSV(
 	ctx context.Context,
 	t *testing.T,
@@ -832,6 +1393,50 @@ func (acl *aclHalfMock) IsOwner(_ context.Context, _ common.Address, _ tables.Ta
 	return true, nil
 }
 
+// receiptPollingStore doesn't find receipts until it's been polled foundAfter times.
+// A negative foundAfter never finds them.
+type receiptPollingStore struct {
+	sqlstore.SystemStore
+
+	foundAfter int
+	calls      int
+}
+
+func (s *receiptPollingStore) GetReceipt(_ context.Context, txnHash string) (eventprocessor.Receipt, bool, error) {
+	s.calls++
+	if s.foundAfter < 0 || s.calls <= s.foundAfter {
+		return eventprocessor.Receipt{}, false, nil
+	}
+	return eventprocessor.Receipt{ChainID: 1337, TxnHash: txnHash, BlockNumber: 100}, true, nil
+}
+
+// registryRecorder records the table ids of the RunSQL calls and the owners of the CreateTable calls it receives.
+type registryRecorder struct {
+	tables.TablelandTables
+
+	tableIDs []string
+	owners   []common.Address
+}
+
+func (r *registryRecorder) CreateTable(
+	_ context.Context,
+	owner common.Address,
+	_ string,
+) (tables.Transaction, error) {
+	r.owners = append(r.owners, owner)
+	return types.NewTx(&types.LegacyTx{}), nil
+}
+
+func (r *registryRecorder) RunSQL(
+	_ context.Context,
+	_ common.Address,
+	id tables.TableID,
+	_ string,
+) (tables.Transaction, error) {
+	r.tableIDs = append(r.tableIDs, id.String())
+	return types.NewTx(&types.LegacyTx{}), nil
+}
+
 func requireReceipts(
 	ctx context.Context,
 	t *testing.T,
@@ -905,7 +1510,9 @@ func requireTxn(
 
 type tablelandSetupBuilder struct {
 	allowTransactionRelay bool
+	maxTableRowCount      int
 	parsingOpts           []parsing.Option
+	mesaOpts              []Option
 }
 
 func newTablelandSetupBuilder() *tablelandSetupBuilder {
@@ -917,11 +1524,21 @@ func (b *tablelandSetupBuilder) withAllowTransactionRelay(v bool) *tablelandSetu
 	return b
 }
 
+func (b *tablelandSetupBuilder) withMaxTableRowCount(v int) *tablelandSetupBuilder {
+	b.maxTableRowCount = v
+	return b
+}
+
 func (b *tablelandSetupBuilder) withParsingOpts(opts ...parsing.Option) *tablelandSetupBuilder {
 	b.parsingOpts = opts
 	return b
 }
 
+func (b *tablelandSetupBuilder) withMesaOpts(opts ...Option) *tablelandSetupBuilder {
+	b.mesaOpts = opts
+	return b
+}
+
 func (b *tablelandSetupBuilder) build(t *testing.T) *tablelandSetup {
 	t.Helper()
 	dbURI := tests.Sqlite3URI(t)
@@ -937,7 +1554,7 @@ func (b *tablelandSetupBuilder) build(t *testing.T) *tablelandSetup {
 	require.NoError(t, err)
 	db.SetMaxOpenConns(1)
 
-	ex, err := executor.NewExecutor(1337, db, parser, 0, &aclHalfMock{store})
+	ex, err := executor.NewExecutor(1337, db, parser, b.maxTableRowCount, &aclHalfMock{store})
 	require.NoError(t, err)
 
 	backend, addr, sc, auth, sk := testutil.Setup(t)
@@ -961,7 +1578,7 @@ func (b *tablelandSetupBuilder) build(t *testing.T) *tablelandSetup {
 	t.Cleanup(func() { ep.Stop() })
 
 	userStore, err := user.New(
-		dbURI, rsresolver.New(map[tableland.ChainID]eventprocessor.EventProcessor{1337: ep}))
+		dbURI, rsresolver.New(map[tableland.ChainID]eventprocessor.EventProcessor{1337: ep}), 0)
 	require.NoError(t, err)
 
 	return &tablelandSetup{
@@ -987,6 +1604,8 @@ func (b *tablelandSetupBuilder) build(t *testing.T) *tablelandSetup {
 
 		// configs
 		allowTransactionRelay: b.allowTransactionRelay,
+		maxTableRowCount:      b.maxTableRowCount,
+		mesaOpts:              b.mesaOpts,
 	}
 }
 
@@ -1013,6 +1632,8 @@ type tablelandSetup struct {
 
 	// configs
 	allowTransactionRelay bool
+	maxTableRowCount      int
+	mesaOpts              []Option
 }
 
 func (s *tablelandSetup) newTablelandClient(t *testing.T) *tablelandClient {
@@ -1041,7 +1662,7 @@ func (s *tablelandSetup) newTablelandClient(t *testing.T) *tablelandClient {
 		impl.NewSimpleTracker(wallet, s.ethClient),
 	)
 	require.NoError(t, err)
-	tbld := NewTablelandMesa(
+	tbld, err := NewTablelandMesa(
 		s.parser,
 		s.userStore,
 		map[tableland.ChainID]chains.ChainStack{
@@ -1049,8 +1670,11 @@ func (s *tablelandSetup) newTablelandClient(t *testing.T) *tablelandClient {
 				Store:                 s.systemStore,
 				Registry:              registry,
 				AllowTransactionRelay: s.allowTransactionRelay,
+				MaxTableRowCount:      s.maxTableRowCount,
 			},
-		})
+		},
+		s.mesaOpts...)
+	require.NoError(t, err)
 
 	return &tablelandClient{
 		tableland: tbld,
@@ -87,11 +87,67 @@ type TxnReceipt struct {
 	TableID       *string `json:"table_id,omitempty"`
 	Error         string  `json:"error"`
 	ErrorEventIdx int     `json:"error_event_idx"`
+	ErrorCode     string  `json:"error_code,omitempty"`
+}
+
+// ErrUnsupportedChain is an error returned when a chain ID isn't supported by the validator.
+type ErrUnsupportedChain struct {
+	ChainID ChainID
+}
+
+func (e *ErrUnsupportedChain) Error() string {
+	return fmt.Sprintf("chain id %d isn't supported in the validator", e.ChainID)
+}
+
+// ErrReceiptWaitTimeout is an error returned when waiting for the receipt of a transaction
+// takes longer than allowed.
+type ErrReceiptWaitTimeout struct {
+	TxnHash string
+	Timeout time.Duration
+}
+
+func (e *ErrReceiptWaitTimeout) Error() string {
+	return fmt.Sprintf("the receipt of txn %s doesn't exist after waiting %s", e.TxnHash, e.Timeout)
+}
+
+// ErrPrivilegeDenied is an error returned when a caller doesn't have the privilege to execute
+// an operation on a table.
+type ErrPrivilegeDenied struct {
+	Caller    common.Address
+	TableID   tables.TableID
+	Operation Operation
+}
+
+func (e *ErrPrivilegeDenied) Error() string {
+	return fmt.Sprintf("%s doesn't have privileges for %s on table %s", e.Caller.Hex(), e.Operation, e.TableID)
+}
+
+// ErrTableCreationDenied is an error returned when table creation is gated and a caller isn't
+// allowed to create tables.
+type ErrTableCreationDenied struct {
+	Caller common.Address
+}
+
+func (e *ErrTableCreationDenied) Error() string {
+	return fmt.Sprintf("%s doesn't have privileges to create tables", e.Caller.Hex())
+}
+
+// ErrRowCountExceeded is an error returned when a write would make a table exceed its row count limit.
+type ErrRowCountExceeded struct {
+	TableID tables.TableID
+	Limit   int
+	After   int
+}
+
+func (e *ErrRowCountExceeded) Error() string {
+	return fmt.Sprintf("table %s would have %d rows, exceeding the limit of %d", e.TableID, e.After, e.Limit)
 }
 
 // Tableland defines the interface of Tableland.
 type Tableland interface {
 	RunReadQuery(ctx context.Context, stmt string) (*TableData, error)
+	RunReadQueryPage(ctx context.Context, stmt string, pageSize int, cursor string) (*TableData, string, error)
+	RunReadQueryAfter(ctx context.Context, chainID ChainID, stmt string, afterTxnHash string) (*TableData, error)
 	ValidateCreateTable(ctx context.Context, chainID ChainID, stmt string) (string, error)
 	ValidateWriteQuery(ctx context.Context, chainID ChainID, stmt string) (tables.TableID, error)
 	RelayWriteQuery(
@@ -100,7 +156,16 @@ type Tableland interface {
 		caller common.Address,
 		stmt string,
 	) (tables.Transaction, error)
+	RelayCreateTable(
+		ctx context.Context,
+		chainID ChainID,
+		caller common.Address,
+		stmt string,
+	) (tables.Transaction, error)
 	GetReceipt(ctx context.Context, chainID ChainID, txnHash string) (bool, *TxnReceipt, error)
+	GetReceipts(ctx context.Context, chainID ChainID, txnHashes []string) (map[string]*TxnReceipt, error)
+	WaitForReceipt(ctx context.Context, chainID ChainID, txnHash string, timeout time.Duration) (*TxnReceipt, error)
+	GetTableJSONSchema(ctx context.Context, chainID ChainID, tableID tables.TableID) ([]byte, error)
 	SetController(
 		ctx context.Context,
 		chainID ChainID,
@@ -125,7 +190,25 @@ func (t Table) ChainID() ChainID {
 	return t.chainID
 }
 
+// ID returns table's id.
+func (t Table) ID() tables.TableID {
+	return t.id
+}
+
+// Prefix returns table's prefix. It can be empty.
+func (t Table) Prefix() string {
+	return t.prefix
+}
+
+// Name returns table's full name in the {prefix}_{chainID}_{tableID} format.
+// If the prefix is empty, the name starts with an underscore (e.g: _1337_42).
+func (t Table) Name() string {
+	return fmt.Sprintf("%s_%d_%s", t.prefix, t.chainID, t.id)
+}
+
 // NewTableFromName creates a Table from its name.
+// The name has the {prefix}_{chainID}_{tableID} format, where the prefix can be empty. A name with only
+// two parts (e.g: 1_42) is considered to have an empty prefix.
 func NewTableFromName(name string) (Table, error) {
 	parts := strings.Split(name, "_")
 
@@ -133,6 +216,16 @@ func NewTableFromName(name string) (Table, error) {
 		return Table{}, errors.New("table name has invalid format")
 	}
 
+	var prefix string
+	if len(parts) > 2 {
+		prefix = strings.Join(parts[:len(parts)-2], "_")
+		// A prefix can't start with a digit, otherwise a name such as 1_2_42
+		// would silently be parsed using the wrong parts.
+		if prefix != "" && prefix[0] >= '0' && prefix[0] <= '9' {
+			return Table{}, errors.New("table name prefix can't start with a digit")
+		}
+	}
+
 	tableID, err := tables.NewTableID(parts[len(parts)-1])
 	if err != nil {
 		return Table{}, fmt.Errorf("new table id: %s", err)
@@ -145,7 +238,7 @@ func NewTableFromName(name string) (Table, error) {
 
 	return Table{
 		id:      tableID,
-		prefix:  strings.Join(parts[:len(parts)-2], "_"),
+		prefix:  prefix,
 		chainID: ChainID(i),
 	}, nil
 }
@@ -0,0 +1,85 @@
+package tableland
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableNameRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		prefix  string
+		chainID ChainID
+		id      string
+	}{
+		{name: "foo_1337_1", prefix: "foo", chainID: 1337, id: "1"},
+		{name: "my_table_1_42", prefix: "my_table", chainID: 1, id: "42"},
+		{name: "a_b_c_80001_100", prefix: "a_b_c", chainID: 80001, id: "100"},
+		{name: "_1337_7", prefix: "", chainID: 1337, id: "7"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			table, err := NewTableFromName(tc.name)
+			require.NoError(t, err)
+			require.Equal(t, tc.prefix, table.Prefix())
+			require.Equal(t, tc.chainID, table.ChainID())
+			require.Equal(t, tc.id, table.ID().String())
+			require.Equal(t, tc.name, table.Name())
+
+			roundTrip, err := NewTableFromName(table.Name())
+			require.NoError(t, err)
+			require.Equal(t, table, roundTrip)
+		})
+	}
+}
+
+func TestNewTableFromName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name    string
+			prefix  string
+			chainID ChainID
+			id      string
+		}{
+			{name: "foo_1_42", prefix: "foo", chainID: 1, id: "42"},
+			{name: "my_table_1_42", prefix: "my_table", chainID: 1, id: "42"},
+			{name: "1_42", prefix: "", chainID: 1, id: "42"},
+			{name: "_1_42", prefix: "", chainID: 1, id: "42"},
+		}
+
+		for _, tc := range tests {
+			table, err := NewTableFromName(tc.name)
+			require.NoError(t, err, tc.name)
+			require.Equal(t, tc.prefix, table.Prefix(), tc.name)
+			require.Equal(t, tc.chainID, table.ChainID(), tc.name)
+			require.Equal(t, tc.id, table.ID().String(), tc.name)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		names := []string{
+			"",
+			"42",
+			"foo_42",
+			"1_foo",
+			"9_1_42",
+		}
+		for _, name := range names {
+			_, err := NewTableFromName(name)
+			require.Error(t, err, name)
+		}
+	})
+}
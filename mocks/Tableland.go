@@ -12,6 +12,8 @@ import (
 	tableland "github.com/textileio/go-tableland/internal/tableland"
 
 	tables "github.com/textileio/go-tableland/pkg/tables"
+
+	time "time"
 )
 
 // Tableland is an autogenerated mock type for the Tableland type
@@ -82,6 +84,151 @@ func (_c *Tableland_GetReceipt_Call) Return(_a0 bool, _a1 *tableland.TxnReceipt,
 	return _c
 }
 
+// GetReceipts provides a mock function with given fields: ctx, chainID, txnHashes
+func (_m *Tableland) GetReceipts(ctx context.Context, chainID tableland.ChainID, txnHashes []string) (map[string]*tableland.TxnReceipt, error) {
+	ret := _m.Called(ctx, chainID, txnHashes)
+
+	var r0 map[string]*tableland.TxnReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, []string) map[string]*tableland.TxnReceipt); ok {
+		r0 = rf(ctx, chainID, txnHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*tableland.TxnReceipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, []string) error); ok {
+		r1 = rf(ctx, chainID, txnHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tableland_GetReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReceipts'
+type Tableland_GetReceipts_Call struct {
+	*mock.Call
+}
+
+// GetReceipts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID tableland.ChainID
+//   - txnHashes []string
+func (_e *Tableland_Expecter) GetReceipts(ctx interface{}, chainID interface{}, txnHashes interface{}) *Tableland_GetReceipts_Call {
+	return &Tableland_GetReceipts_Call{Call: _e.mock.On("GetReceipts", ctx, chainID, txnHashes)}
+}
+
+func (_c *Tableland_GetReceipts_Call) Run(run func(ctx context.Context, chainID tableland.ChainID, txnHashes []string)) *Tableland_GetReceipts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *Tableland_GetReceipts_Call) Return(_a0 map[string]*tableland.TxnReceipt, _a1 error) *Tableland_GetReceipts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetTableJSONSchema provides a mock function with given fields: ctx, chainID, tableID
+func (_m *Tableland) GetTableJSONSchema(ctx context.Context, chainID tableland.ChainID, tableID tables.TableID) ([]byte, error) {
+	ret := _m.Called(ctx, chainID, tableID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, tables.TableID) []byte); ok {
+		r0 = rf(ctx, chainID, tableID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, tables.TableID) error); ok {
+		r1 = rf(ctx, chainID, tableID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tableland_GetTableJSONSchema_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTableJSONSchema'
+type Tableland_GetTableJSONSchema_Call struct {
+	*mock.Call
+}
+
+// GetTableJSONSchema is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID tableland.ChainID
+//   - tableID tables.TableID
+func (_e *Tableland_Expecter) GetTableJSONSchema(ctx interface{}, chainID interface{}, tableID interface{}) *Tableland_GetTableJSONSchema_Call {
+	return &Tableland_GetTableJSONSchema_Call{Call: _e.mock.On("GetTableJSONSchema", ctx, chainID, tableID)}
+}
+
+func (_c *Tableland_GetTableJSONSchema_Call) Run(run func(ctx context.Context, chainID tableland.ChainID, tableID tables.TableID)) *Tableland_GetTableJSONSchema_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(tables.TableID))
+	})
+	return _c
+}
+
+func (_c *Tableland_GetTableJSONSchema_Call) Return(_a0 []byte, _a1 error) *Tableland_GetTableJSONSchema_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RelayCreateTable provides a mock function with given fields: ctx, chainID, caller, stmt
+func (_m *Tableland) RelayCreateTable(ctx context.Context, chainID tableland.ChainID, caller common.Address, stmt string) (tables.Transaction, error) {
+	ret := _m.Called(ctx, chainID, caller, stmt)
+
+	var r0 tables.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, common.Address, string) tables.Transaction); ok {
+		r0 = rf(ctx, chainID, caller, stmt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tables.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, common.Address, string) error); ok {
+		r1 = rf(ctx, chainID, caller, stmt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tableland_RelayCreateTable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelayCreateTable'
+type Tableland_RelayCreateTable_Call struct {
+	*mock.Call
+}
+
+// RelayCreateTable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID tableland.ChainID
+//   - caller common.Address
+//   - stmt string
+func (_e *Tableland_Expecter) RelayCreateTable(ctx interface{}, chainID interface{}, caller interface{}, stmt interface{}) *Tableland_RelayCreateTable_Call {
+	return &Tableland_RelayCreateTable_Call{Call: _e.mock.On("RelayCreateTable", ctx, chainID, caller, stmt)}
+}
+
+func (_c *Tableland_RelayCreateTable_Call) Run(run func(ctx context.Context, chainID tableland.ChainID, caller common.Address, stmt string)) *Tableland_RelayCreateTable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(common.Address), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Tableland_RelayCreateTable_Call) Return(_a0 tables.Transaction, _a1 error) *Tableland_RelayCreateTable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // RelayWriteQuery provides a mock function with given fields: ctx, chainID, caller, stmt
 func (_m *Tableland) RelayWriteQuery(ctx context.Context, chainID tableland.ChainID, caller common.Address, stmt string) (tables.Transaction, error) {
 	ret := _m.Called(ctx, chainID, caller, stmt)
@@ -178,6 +325,111 @@ func (_c *Tableland_RunReadQuery_Call) Return(_a0 *tableland.TableData, _a1 erro
 	return _c
 }
 
+// RunReadQueryAfter provides a mock function with given fields: ctx, chainID, stmt, afterTxnHash
+func (_m *Tableland) RunReadQueryAfter(ctx context.Context, chainID tableland.ChainID, stmt string, afterTxnHash string) (*tableland.TableData, error) {
+	ret := _m.Called(ctx, chainID, stmt, afterTxnHash)
+
+	var r0 *tableland.TableData
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, string, string) *tableland.TableData); ok {
+		r0 = rf(ctx, chainID, stmt, afterTxnHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tableland.TableData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, string, string) error); ok {
+		r1 = rf(ctx, chainID, stmt, afterTxnHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tableland_RunReadQueryAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunReadQueryAfter'
+type Tableland_RunReadQueryAfter_Call struct {
+	*mock.Call
+}
+
+// RunReadQueryAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID tableland.ChainID
+//   - stmt string
+//   - afterTxnHash string
+func (_e *Tableland_Expecter) RunReadQueryAfter(ctx interface{}, chainID interface{}, stmt interface{}, afterTxnHash interface{}) *Tableland_RunReadQueryAfter_Call {
+	return &Tableland_RunReadQueryAfter_Call{Call: _e.mock.On("RunReadQueryAfter", ctx, chainID, stmt, afterTxnHash)}
+}
+
+func (_c *Tableland_RunReadQueryAfter_Call) Run(run func(ctx context.Context, chainID tableland.ChainID, stmt string, afterTxnHash string)) *Tableland_RunReadQueryAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Tableland_RunReadQueryAfter_Call) Return(_a0 *tableland.TableData, _a1 error) *Tableland_RunReadQueryAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RunReadQueryPage provides a mock function with given fields: ctx, stmt, pageSize, cursor
+func (_m *Tableland) RunReadQueryPage(ctx context.Context, stmt string, pageSize int, cursor string) (*tableland.TableData, string, error) {
+	ret := _m.Called(ctx, stmt, pageSize, cursor)
+
+	var r0 *tableland.TableData
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) *tableland.TableData); ok {
+		r0 = rf(ctx, stmt, pageSize, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tableland.TableData)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) string); ok {
+		r1 = rf(ctx, stmt, pageSize, cursor)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, string) error); ok {
+		r2 = rf(ctx, stmt, pageSize, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Tableland_RunReadQueryPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunReadQueryPage'
+type Tableland_RunReadQueryPage_Call struct {
+	*mock.Call
+}
+
+// RunReadQueryPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stmt string
+//   - pageSize int
+//   - cursor string
+func (_e *Tableland_Expecter) RunReadQueryPage(ctx interface{}, stmt interface{}, pageSize interface{}, cursor interface{}) *Tableland_RunReadQueryPage_Call {
+	return &Tableland_RunReadQueryPage_Call{Call: _e.mock.On("RunReadQueryPage", ctx, stmt, pageSize, cursor)}
+}
+
+func (_c *Tableland_RunReadQueryPage_Call) Run(run func(ctx context.Context, stmt string, pageSize int, cursor string)) *Tableland_RunReadQueryPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Tableland_RunReadQueryPage_Call) Return(_a0 *tableland.TableData, _a1 string, _a2 error) *Tableland_RunReadQueryPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
 // SetController provides a mock function with given fields: ctx, chainID, caller, controller, tableID
 func (_m *Tableland) SetController(ctx context.Context, chainID tableland.ChainID, caller common.Address, controller common.Address, tableID tables.TableID) (tables.Transaction, error) {
 	ret := _m.Called(ctx, chainID, caller, controller, tableID)
@@ -320,6 +572,55 @@ func (_c *Tableland_ValidateWriteQuery_Call) Return(_a0 tables.TableID, _a1 erro
 	return _c
 }
 
+// WaitForReceipt provides a mock function with given fields: ctx, chainID, txnHash, timeout
+func (_m *Tableland) WaitForReceipt(ctx context.Context, chainID tableland.ChainID, txnHash string, timeout time.Duration) (*tableland.TxnReceipt, error) {
+	ret := _m.Called(ctx, chainID, txnHash, timeout)
+
+	var r0 *tableland.TxnReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, string, time.Duration) *tableland.TxnReceipt); ok {
+		r0 = rf(ctx, chainID, txnHash, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tableland.TxnReceipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, string, time.Duration) error); ok {
+		r1 = rf(ctx, chainID, txnHash, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tableland_WaitForReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WaitForReceipt'
+type Tableland_WaitForReceipt_Call struct {
+	*mock.Call
+}
+
+// WaitForReceipt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID tableland.ChainID
+//   - txnHash string
+//   - timeout time.Duration
+func (_e *Tableland_Expecter) WaitForReceipt(ctx interface{}, chainID interface{}, txnHash interface{}, timeout interface{}) *Tableland_WaitForReceipt_Call {
+	return &Tableland_WaitForReceipt_Call{Call: _e.mock.On("WaitForReceipt", ctx, chainID, txnHash, timeout)}
+}
+
+func (_c *Tableland_WaitForReceipt_Call) Run(run func(ctx context.Context, chainID tableland.ChainID, txnHash string, timeout time.Duration)) *Tableland_WaitForReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Tableland_WaitForReceipt_Call) Return(_a0 *tableland.TxnReceipt, _a1 error) *Tableland_WaitForReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 type mockConstructorTestingTNewTableland interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,100 @@
+package eventfeed
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/textileio/go-tableland/internal/tableland"
+	tbleth "github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
+)
+
+// ErrUnknownEventType is an error returned when decoding an event that isn't a
+// supported Registry smart contract event.
+type ErrUnknownEventType struct {
+	EventType string
+}
+
+func (e *ErrUnknownEventType) Error() string {
+	return fmt.Sprintf("unknown event type %s", e.EventType)
+}
+
+// DecodeEvent decodes a persisted EVM event into the corresponding auto-generated
+// struct of the Registry smart contract, e.g: *tbleth.ContractCreateTable.
+func DecodeEvent(e tableland.EVMEvent) (interface{}, error) {
+	var topicsHex []string
+	if err := json.Unmarshal(e.Topics, &topicsHex); err != nil {
+		return nil, fmt.Errorf("unmarshaling topics: %s", err)
+	}
+	topics := make([]common.Hash, len(topicsHex))
+	for i, topic := range topicsHex {
+		topics[i] = common.HexToHash(topic)
+	}
+
+	scABI, err := tbleth.ContractMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("get contract-abi: %s", err)
+	}
+
+	return DecodeLog(scABI, types.Log{
+		Address:     e.Address,
+		Topics:      topics,
+		Data:        e.Data,
+		BlockNumber: e.BlockNumber,
+		TxHash:      e.TxHash,
+		TxIndex:     e.TxIndex,
+		BlockHash:   e.BlockHash,
+		Index:       e.Index,
+	})
+}
+
+// DecodeLog deconstructs a raw log of the Registry smart contract to a structured representation.
+// Since the event can be from different types, we return an interface.
+// Every possible type in the interface{} is an auto-generated struct by
+// `make ethereum` named `Contract*` (e.g: ContractRunSQL, ContractTransfer, etc).
+// See this mapping in the SupportedEvents map.
+func DecodeLog(scABI *abi.ABI, l types.Log) (interface{}, error) {
+	if len(l.Topics) == 0 {
+		return nil, &ErrUnknownEventType{}
+	}
+	// We get an event descriptior from the common.Hash value that is always
+	// in Topic[0] in events. This is an ID for the kind of event.
+	eventDescr, err := scABI.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, &ErrUnknownEventType{EventType: l.Topics[0].Hex()}
+	}
+
+	se, ok := SupportedEvents[EventType(eventDescr.Name)]
+	if !ok {
+		return nil, &ErrUnknownEventType{EventType: eventDescr.Name}
+	}
+	// Create a new *ContractXXXX struct that corresponds to this event.
+	// e.g: *ContractRunSQL if this event was one fired by runSQL(..) SC function.
+	i := reflect.New(se).Interface()
+
+	// Now we unmarshal the event data, to the *ContractXXX struct.
+	// First, we unmarshal the information contained in the `data` of the event, which
+	// are non-indexed fields of the event.
+	if len(l.Data) > 0 {
+		if err := scABI.UnpackIntoInterface(i, eventDescr.Name, l.Data); err != nil {
+			return nil, fmt.Errorf("unpacking into interface: %s", err)
+		}
+	}
+	// Second, we unmarshal indexed fields which aren't in data but in Topics[1:].
+	var indexed abi.Arguments
+	for _, arg := range eventDescr.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(i, indexed, l.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("unpacking indexed topics: %s", err)
+	}
+	// Note that the above two steps of unmarshalling isn't something particular
+	// to us, it's just how Ethereum works.
+
+	return i, nil
+}
@@ -0,0 +1,51 @@
+package eventfeed
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
+	tbleth "github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	t.Parallel()
+
+	scABI, err := tbleth.ContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	t.Run("create table", func(t *testing.T) {
+		t.Parallel()
+
+		owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+		stmt := "CREATE TABLE foo_1337 (bar int)"
+		eventDescr := scABI.Events["CreateTable"]
+		data, err := eventDescr.Inputs.Pack(owner, big.NewInt(42), stmt)
+		require.NoError(t, err)
+		topics, err := json.Marshal([]string{eventDescr.ID.Hex()})
+		require.NoError(t, err)
+
+		event, err := DecodeEvent(tableland.EVMEvent{Topics: topics, Data: data})
+		require.NoError(t, err)
+
+		createTable, ok := event.(*tbleth.ContractCreateTable)
+		require.True(t, ok)
+		require.Equal(t, owner, createTable.Owner)
+		require.Equal(t, int64(42), createTable.TableId.Int64())
+		require.Equal(t, stmt, createTable.Statement)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		t.Parallel()
+
+		topics, err := json.Marshal([]string{common.HexToHash("0xdeadbeef").Hex()})
+		require.NoError(t, err)
+
+		_, err = DecodeEvent(tableland.EVMEvent{Topics: topics})
+		var errUnknownEventType *ErrUnknownEventType
+		require.ErrorAs(t, err, &errUnknownEventType)
+	})
+}
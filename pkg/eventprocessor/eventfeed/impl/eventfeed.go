@@ -249,51 +249,16 @@ func (ef *EventFeed) packEvents(logs []types.Log, parsedEvents []interface{}) []
 }
 
 // parseEvent deconstructs a raw event that was received from the Ethereum node,
-// to a structured representation. Since the event can be from different types,
-// we return an interface.
-// Every possible type in the interface{} is an auto-generated struct by
-// `make ethereum` named `Contract*` (e.g: ContractRunSQL, ContractTransfer, etc).
-// See this mapping in the `supportedEvents` map global variable in this file.
+// to a structured representation. See eventfeed.DecodeLog for details.
 func (ef *EventFeed) parseEvent(l types.Log) (interface{}, error) {
-	// We get an event descriptior from the common.Hash value that is always
-	// in Topic[0] in events. This is an ID for the kind of event.
-	eventDescr, err := ef.scABI.EventByID(l.Topics[0])
+	i, err := eventfeed.DecodeLog(ef.scABI, l)
 	if err != nil {
-		return eventfeed.TxnEvents{}, fmt.Errorf("detecting event type: %s", err)
+		return eventfeed.TxnEvents{}, err
 	}
 
-	se, ok := eventfeed.SupportedEvents[eventfeed.EventType(eventDescr.Name)]
-	if !ok {
-		return eventfeed.TxnEvents{}, fmt.Errorf("unknown event type %s", eventDescr.Name)
-	}
-	// Create a new *ContractXXXX struct that corresponds to this event.
-	// e.g: *ContractRunSQL if this event was one fired by runSQL(..) SC function.
-	i := reflect.New(se).Interface()
-
-	// Now we unmarshal the event data, to the *ContractXXX struct.
-	// First, we unmarshal the information contained in the `data` of the event, which
-	// are non-indexed fields of the event.
-	if len(l.Data) > 0 {
-		if err := ef.scABI.UnpackIntoInterface(i, eventDescr.Name, l.Data); err != nil {
-			return eventfeed.TxnEvents{}, fmt.Errorf("unpacking into interface: %s", err)
-		}
-	}
-	// Second, we unmarshal indexed fields which aren't in data but in Topics[1:].
-	var indexed abi.Arguments
-	for _, arg := range eventDescr.Inputs {
-		if arg.Indexed {
-			indexed = append(indexed, arg)
-		}
-	}
-	if err := abi.ParseTopics(i, indexed, l.Topics[1:]); err != nil {
-		return eventfeed.TxnEvents{}, fmt.Errorf("unpacking indexed topics: %s", err)
-	}
-	// Note that the above two steps of unmarshalling isn't something particular
-	// to us, it's just how Ethereum works.
-	// This parsedEvent(...) function was coded in a generic way, so it will hardly
-	// ever change.
-
-	attrs := append([]attribute.KeyValue{attribute.String("name", eventDescr.Name)}, ef.mBaseLabels...)
+	// The struct names are ContractXXXX, where XXXX is the event name.
+	eventName := strings.TrimPrefix(reflect.TypeOf(i).Elem().Name(), "Contract")
+	attrs := append([]attribute.KeyValue{attribute.String("name", eventName)}, ef.mBaseLabels...)
 	ef.mEventTypeCounter.Add(context.Background(), 1, attrs...)
 
 	return i, nil
@@ -81,4 +81,39 @@ type Receipt struct {
 	TableID       *tables.TableID
 	Error         *string
 	ErrorEventIdx *int
+	// ErrorCode is one of the ErrorCode* constants, describing why the txn failed. It's empty for
+	// receipts saved before error codes existed.
+	ErrorCode string
 }
+
+// Error codes of failed txn receipts. The set of codes is fixed, so clients can branch on them.
+const (
+	// ErrorCodeInvalidSyntax means a statement can't be parsed, or isn't valid in Tableland.
+	ErrorCodeInvalidSyntax = "invalid_syntax"
+	// ErrorCodeEmptyBatch means a batch has no statements.
+	ErrorCodeEmptyBatch = "empty_batch"
+	// ErrorCodeTooManyStatements means a batch has more statements than allowed.
+	ErrorCodeTooManyStatements = "too_many_statements"
+	// ErrorCodeMissingTableID means the event doesn't have a table id.
+	ErrorCodeMissingTableID = "missing_table_id"
+	// ErrorCodeTableIDMismatch means the statements reference a table other than the one of the event.
+	ErrorCodeTableIDMismatch = "table_id_mismatch"
+	// ErrorCodeTableIDConflict means a table with the same id and a different structure already exists.
+	ErrorCodeTableIDConflict = "table_id_conflict"
+	// ErrorCodeTableNotFound means the referenced table doesn't exist.
+	ErrorCodeTableNotFound = "table_not_found"
+	// ErrorCodeACLDenied means the caller doesn't have the privileges the statements need.
+	ErrorCodeACLDenied = "acl_denied"
+	// ErrorCodePolicyDenied means the controller policy of the table rejected the statements.
+	ErrorCodePolicyDenied = "policy_denied"
+	// ErrorCodeRowCountExceeded means the statements exceed the row count limit of the table.
+	ErrorCodeRowCountExceeded = "row_count_exceeded"
+	// ErrorCodeConstraintViolation means the statements violate a constraint of the table.
+	ErrorCodeConstraintViolation = "constraint_violation"
+	// ErrorCodeTypeMismatch means a value doesn't match the type of its column.
+	ErrorCodeTypeMismatch = "type_mismatch"
+	// ErrorCodeValueTooBig means a string or blob value exceeds the size limit.
+	ErrorCodeValueTooBig = "value_too_big"
+	// ErrorCodeExecutionFailed means the database failed to execute a statement for any other reason.
+	ErrorCodeExecutionFailed = "execution_failed"
+)
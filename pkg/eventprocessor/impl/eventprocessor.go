@@ -252,6 +252,7 @@ func (ep *EventProcessor) executeBlock(ctx context.Context, block eventfeed.Bloc
 			TableID:       txnExecResult.TableID,
 			Error:         txnExecResult.Error,
 			ErrorEventIdx: txnExecResult.ErrorEventIdx,
+			ErrorCode:     txnExecResult.ErrorCode,
 		}
 		receipts = append(receipts, receipt)
 
@@ -374,7 +374,7 @@ func setup(t *testing.T) (
 
 	require.NoError(t, err)
 	userStore, err := user.New(
-		dbURI, rsresolver.New(map[tableland.ChainID]eventprocessor.EventProcessor{chainID: ep}))
+		dbURI, rsresolver.New(map[tableland.ChainID]eventprocessor.EventProcessor{chainID: ep}), 0)
 	require.NoError(t, err)
 
 	tableReader := func(readQuery string) []int64 {
@@ -2,6 +2,9 @@ package executor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/internal/tableland"
@@ -13,11 +16,22 @@ import (
 // Executor provides a safe way of executing events contained in an EVM blockchain block.
 type Executor interface {
 	// NewBlockScope returns a new block scope which can execute events generated by EVM-transactions.
+	// Only one block scope can be open at a time, so it waits for any open block scope to be closed
+	// or returns the context error if the provided context gets canceled first.
 	NewBlockScope(context.Context, int64) (BlockScope, error)
 
 	// GetLastExecutedBlockNumber returns the last executed block number.
 	GetLastExecutedBlockNumber(ctx context.Context) (int64, error)
 
+	// GetTableRowCount returns the current number of rows of a table, as of the last committed block scope.
+	// It doesn't require an open block scope. If the table doesn't exist, it returns an *ErrTableNotExist error.
+	GetTableRowCount(ctx context.Context, id tables.TableID) (int, error)
+
+	// EstimateAffectedRows returns the number of rows of a table that match a WHERE clause, which is the
+	// number of rows an UPDATE or DELETE with that clause would affect, as of the last committed block scope.
+	// An empty clause matches all the rows. If the table doesn't exist, it returns an *ErrTableNotExist error.
+	EstimateAffectedRows(ctx context.Context, id tables.TableID, whereClause string) (int, error)
+
 	// Close gracefully closes the executor, waiting for any block scope to be gracefully closed or force closing
 	// if the provided context gets canceled.
 	Close(context.Context) error
@@ -42,6 +56,25 @@ type BlockScope interface {
 	// TxnReceiptExists return true if the provided transaction hash was already processed, and false otherwise.
 	TxnReceiptExists(ctx context.Context, txnHash common.Hash) (bool, error)
 
+	// DropTable removes a table owned by the provided address, together with its registry and ACL entries.
+	// If the table doesn't exist, it returns an *ErrTableNotExist error.
+	DropTable(ctx context.Context, id tables.TableID, owner common.Address) error
+
+	// TransferOwnership hands over the ownership of a table owned by caller to newOwner, the same way a
+	// transfer event does. If the table doesn't exist, it returns an *ErrTableNotExist error, and if
+	// newOwner isn't allowed, an *ErrInvalidOwner error.
+	TransferOwnership(ctx context.Context, id tables.TableID, caller common.Address, newOwner common.Address) error
+
+	// SetTableDescription updates the description of a table owned by the provided address.
+	// If the table doesn't exist, it returns an *ErrTableNotExist error, and if the description is
+	// longer than allowed, an *ErrDescriptionTooLong error.
+	SetTableDescription(ctx context.Context, id tables.TableID, owner common.Address, description string) error
+
+	// RenameTable changes the prefix of a table owned by the provided address. The table data and its
+	// structure aren't changed. If the table doesn't exist, it returns an *ErrTableNotExist error, and if
+	// the new prefix doesn't follow the table naming rules, an *ErrInvalidTablePrefix error.
+	RenameTable(ctx context.Context, id tables.TableID, owner common.Address, newPrefix string) error
+
 	// StateHash calculates the hash of some state of the database.
 	StateHash(ctx context.Context, chainID tableland.ChainID) (StateHash, error)
 
@@ -53,12 +86,108 @@ type BlockScope interface {
 	Close() error
 }
 
+// ErrTableNotExist is returned when the operation targets a table that doesn't exist.
+type ErrTableNotExist struct {
+	TableID tables.TableID
+}
+
+func (e *ErrTableNotExist) Error() string {
+	return fmt.Sprintf("table with id %s doesn't exist", e.TableID)
+}
+
+// ErrTableIDConflict is returned when a table is created with an ID that's already registered
+// for a table with a different structure.
+type ErrTableIDConflict struct {
+	TableID           tables.TableID
+	Structure         string
+	ExistingStructure string
+}
+
+func (e *ErrTableIDConflict) Error() string {
+	return fmt.Sprintf("table with id %s already exists with structure %s (new structure %s)",
+		e.TableID, e.ExistingStructure, e.Structure)
+}
+
+// ErrInvalidOwner is returned when the new owner of a table isn't a valid address.
+// The zero address is only valid if locking tables is allowed.
+type ErrInvalidOwner struct {
+	Owner common.Address
+}
+
+func (e *ErrInvalidOwner) Error() string {
+	return fmt.Sprintf("%s isn't a valid owner", e.Owner.Hex())
+}
+
+// ErrDescriptionTooLong is returned when a table description is longer than allowed.
+type ErrDescriptionTooLong struct {
+	Length int
+	Max    int
+}
+
+func (e *ErrDescriptionTooLong) Error() string {
+	return fmt.Sprintf("description has %d characters but the maximum allowed is %d", e.Length, e.Max)
+}
+
+// ErrInvalidTablePrefix is returned when a table is renamed to a prefix that isn't allowed
+// in a CREATE TABLE statement.
+type ErrInvalidTablePrefix struct {
+	Prefix string
+}
+
+func (e *ErrInvalidTablePrefix) Error() string {
+	return fmt.Sprintf("%q isn't a valid table prefix", e.Prefix)
+}
+
+// ErrEmptyBatch is returned when a write query has no statements and empty batches are rejected.
+var ErrEmptyBatch = errors.New("the write query has no statements")
+
+// ErrTooManyStatements is returned when a write query has more statements than allowed.
+type ErrTooManyStatements struct {
+	Have int
+	Max  int
+}
+
+func (e *ErrTooManyStatements) Error() string {
+	return fmt.Sprintf("the query has %d statements but the maximum allowed is %d", e.Have, e.Max)
+}
+
+type blockTimeKey struct{}
+
+// ContextWithBlockTime returns a copy of ctx carrying the timestamp of the block about to be executed.
+// A block scope opened with that context uses the block time instead of the local clock, so what it
+// records doesn't depend on when or where the block is executed.
+func ContextWithBlockTime(ctx context.Context, blockTime time.Time) context.Context {
+	return context.WithValue(ctx, blockTimeKey{}, blockTime)
+}
+
+// BlockTimeFromContext returns the block timestamp carried by ctx, if any.
+func BlockTimeFromContext(ctx context.Context) (time.Time, bool) {
+	blockTime, ok := ctx.Value(blockTimeKey{}).(time.Time)
+	return blockTime, ok
+}
+
+// MetricsRecorder receives measurements taken while executing block scopes.
+// Implementations must be safe to call from the goroutine executing the block scope.
+type MetricsRecorder interface {
+	// RecordBatchDuration records the elapsed time between opening and committing a block scope.
+	RecordBatchDuration(d time.Duration)
+
+	// RecordStmtExecuted records a successfully executed write statement and the number of affected rows.
+	RecordStmtExecuted(op tableland.Operation, rowsAffected int64)
+
+	// RecordRejection records a statement rejected by the ACL or a controller policy.
+	// The code is the same code reported in the error message of the failed txn receipt (e.g: ACL, POLICY).
+	RecordRejection(code string)
+}
+
 // TxnExecutionResult contains the result of executing a txn with all contained events.
 type TxnExecutionResult struct {
 	TableID *tables.TableID
 
 	Error         *string
 	ErrorEventIdx *int
+	// ErrorCode is one of the eventprocessor.ErrorCode* constants, describing why the txn failed.
+	ErrorCode string
 }
 
 // StateHash represents the state of the database at given block number for a particular chain id.
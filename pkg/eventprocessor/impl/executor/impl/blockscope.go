@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
@@ -15,30 +16,42 @@ import (
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
 )
 
 type blockScope struct {
-	txn    *sql.Tx
-	log    zerolog.Logger
-	parser parsing.SQLValidator
-	acl    tableland.ACL
+	txn     *sql.Tx
+	stmts   *stmtCache
+	log     zerolog.Logger
+	parser  parsing.SQLValidator
+	acl     tableland.ACL
+	metrics executor.MetricsRecorder
 
 	scopeVars scopeVars
+	openedAt  time.Time
 
 	closed func()
 }
 
 type scopeVars struct {
-	ChainID          tableland.ChainID
-	MaxTableRowCount int
-	BlockNumber      int64
+	ChainID            tableland.ChainID
+	MaxTableRowCount   int
+	BlockNumber        int64
+	MaxBatchStatements int
+	RejectEmptyBatches bool
+	MaxDescriptionLen  int
+	AllowTableLocking  bool
+	// BlockTime is the timestamp of the block, or the zero time if it isn't known.
+	BlockTime time.Time
 }
 
 func newBlockScope(
 	txn *sql.Tx,
+	stmts *stmtCache,
 	scopeVars scopeVars,
 	parser parsing.SQLValidator,
 	acl tableland.ACL,
+	metrics executor.MetricsRecorder,
 	closed func(),
 ) *blockScope {
 	log := logger.With().
@@ -49,10 +62,13 @@ func newBlockScope(
 
 	return &blockScope{
 		txn:       txn,
+		stmts:     stmts,
 		log:       log,
 		parser:    parser,
 		acl:       acl,
+		metrics:   metrics,
 		scopeVars: scopeVars,
+		openedAt:  time.Now(),
 		closed:    closed,
 	}
 }
@@ -74,22 +90,10 @@ func (bs *blockScope) ExecuteTxnEvents(
 		return executor.TxnExecutionResult{}, fmt.Errorf("creating savepoint: %s", err)
 	}
 
-	ts := &txnScope{
-		scopeVars: bs.scopeVars,
-
-		parser:            bs.parser,
-		statementResolver: newWriteStatementResolver(evmTxn.TxnHash.Hex(), bs.scopeVars.BlockNumber),
-
-		acl: bs.acl,
-
-		log: logger.With().
-			Str("component", "txnscope").
-			Int64("chain_id", int64(bs.scopeVars.ChainID)).
-			Str("txn_hash", evmTxn.TxnHash.String()).
-			Logger(),
-
-		txn: bs.txn,
-	}
+	ts := bs.newTxnScope()
+	ts.statementResolver = newWriteStatementResolver(evmTxn.TxnHash.Hex(), bs.scopeVars.BlockNumber)
+	ts.txnHash = evmTxn.TxnHash
+	ts.log = ts.log.With().Str("txn_hash", evmTxn.TxnHash.String()).Logger()
 	res, err := ts.executeTxnEvents(ctx, evmTxn)
 	if err != nil || res.Error != nil {
 		if _, err := bs.txn.ExecContext(ctx, "ROLLBACK TO txnscope"); err != nil {
@@ -106,6 +110,103 @@ func (bs *blockScope) ExecuteTxnEvents(
 	return res, nil
 }
 
+// DropTable drops a table if the provided owner is the table owner.
+// All changes are rolled back if the drop fails.
+func (bs *blockScope) DropTable(ctx context.Context, id tables.TableID, owner common.Address) error {
+	if err := bs.withSavepoint(ctx, "droptable", func(ts *txnScope) error {
+		return ts.dropTable(ctx, id, owner)
+	}); err != nil {
+		return fmt.Errorf("dropping table: %w", err)
+	}
+
+	return nil
+}
+
+// TransferOwnership hands over the ownership of a table if the provided caller is the table owner.
+// All changes are rolled back if it fails.
+func (bs *blockScope) TransferOwnership(
+	ctx context.Context,
+	id tables.TableID,
+	caller common.Address,
+	newOwner common.Address,
+) error {
+	if err := bs.withSavepoint(ctx, "transferownership", func(ts *txnScope) error {
+		return ts.transferOwnership(ctx, id, caller, newOwner)
+	}); err != nil {
+		return fmt.Errorf("transferring ownership: %w", err)
+	}
+
+	return nil
+}
+
+// SetTableDescription updates the description of a table if the provided owner is the table owner.
+// All changes are rolled back if it fails.
+func (bs *blockScope) SetTableDescription(
+	ctx context.Context,
+	id tables.TableID,
+	owner common.Address,
+	description string,
+) error {
+	if err := bs.withSavepoint(ctx, "settabledescription", func(ts *txnScope) error {
+		return ts.setTableDescription(ctx, id, owner, description)
+	}); err != nil {
+		return fmt.Errorf("setting table description: %w", err)
+	}
+
+	return nil
+}
+
+// RenameTable changes the prefix of a table if the provided owner is the table owner.
+// All changes are rolled back if the rename fails.
+func (bs *blockScope) RenameTable(
+	ctx context.Context,
+	id tables.TableID,
+	owner common.Address,
+	newPrefix string,
+) error {
+	if err := bs.withSavepoint(ctx, "renametable", func(ts *txnScope) error {
+		return ts.renameTable(ctx, id, owner, newPrefix)
+	}); err != nil {
+		return fmt.Errorf("renaming table: %w", err)
+	}
+
+	return nil
+}
+
+// newTxnScope returns a txn scope that executes in the block scope database transaction.
+func (bs *blockScope) newTxnScope() *txnScope {
+	return &txnScope{
+		scopeVars: bs.scopeVars,
+		parser:    bs.parser,
+		acl:       bs.acl,
+		metrics:   bs.metrics,
+		log: logger.With().
+			Str("component", "txnscope").
+			Int64("chain_id", int64(bs.scopeVars.ChainID)).
+			Logger(),
+		txn:   bs.txn,
+		stmts: bs.stmts,
+	}
+}
+
+// withSavepoint runs fn with a txn scope inside the named savepoint, which is rolled back if fn fails.
+func (bs *blockScope) withSavepoint(ctx context.Context, name string, fn func(ts *txnScope) error) error {
+	if _, err := bs.txn.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("creating savepoint: %s", err)
+	}
+	if err := fn(bs.newTxnScope()); err != nil {
+		if _, err := bs.txn.ExecContext(ctx, "ROLLBACK TO "+name); err != nil {
+			return fmt.Errorf("rollbacking savepoint: %s", err)
+		}
+		return err
+	}
+	if _, err := bs.txn.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("releasing savepoint: %s", err)
+	}
+
+	return nil
+}
+
 func (bs *blockScope) SetLastProcessedHeight(ctx context.Context, height int64) error {
 	tag, err := bs.txn.ExecContext(
 		ctx,
@@ -140,11 +241,13 @@ func (bs *blockScope) SaveTxnReceipts(ctx context.Context, rs []eventprocessor.R
 		if r.Error != nil {
 			*r.Error = strings.ToValidUTF8(*r.Error, "")
 		}
+		errorCode := sql.NullString{String: r.ErrorCode, Valid: r.ErrorCode != ""}
 		if _, err := bs.txn.ExecContext(
 			ctx,
-			`INSERT INTO system_txn_receipts (chain_id,txn_hash,error,error_event_idx,table_id,block_number,index_in_block) 
-				 VALUES (?1,?2,?3,?4,?5,?6,?7)`,
-			r.ChainID, r.TxnHash, r.Error, r.ErrorEventIdx, tableID, r.BlockNumber, r.IndexInBlock); err != nil {
+			`INSERT INTO system_txn_receipts 
+				(chain_id,txn_hash,error,error_event_idx,table_id,block_number,index_in_block,error_code) 
+				 VALUES (?1,?2,?3,?4,?5,?6,?7,?8)`,
+			r.ChainID, r.TxnHash, r.Error, r.ErrorEventIdx, tableID, r.BlockNumber, r.IndexInBlock, errorCode); err != nil {
 			return fmt.Errorf("insert txn receipt: %s", err)
 		}
 	}
@@ -167,6 +270,9 @@ func (bs *blockScope) TxnReceiptExists(ctx context.Context, txnHash common.Hash)
 	return true, nil
 }
 
+// StateHash returns the hash of the chain tables and the system tables that are part of the consensus.
+// The error_code column of system_txn_receipts only classifies the receipt error, so it's left out of
+// both the hashed schema and the hashed rows.
 func (bs *blockScope) StateHash(ctx context.Context, chainID tableland.ChainID) (executor.StateHash, error) {
 	hash, err := dbhash.DatabaseStateHash(ctx, bs.txn, []dbhash.Option{
 		dbhash.WithFetchSchemasQuery(
@@ -176,7 +282,7 @@ func (bs *blockScope) StateHash(ctx context.Context, chainID tableland.ChainID)
 				AND name LIKE '%%\_%d\_%%' ESCAPE '\'
 				AND type = 'table'
 				UNION ALL
-				SELECT tbl_name, sql 
+				SELECT tbl_name, replace(sql, ', error_code TEXT', '') 
 				FROM sqlite_schema
 				WHERE name in ('registry', 'system_acl', 'system_controller', 'system_txn_receipts')
 				ORDER BY tbl_name;`, chainID),
@@ -220,6 +326,10 @@ func (bs *blockScope) StateHash(ctx context.Context, chainID tableland.ChainID)
 func (bs *blockScope) Close() error {
 	defer bs.closed()
 
+	if err := bs.stmts.Close(); err != nil {
+		bs.log.Warn().Err(err).Msg("closing cached statements")
+	}
+
 	// Calling rollback is always safe:
 	// - If Commit() wasn't called, the result is a rollback.
 	// - If Commit() was called, *sql.Txn guarantees is a noop.
@@ -236,6 +346,7 @@ func (bs *blockScope) Commit() error {
 	if err := bs.txn.Commit(); err != nil {
 		return fmt.Errorf("commit db txn: %s", err)
 	}
+	bs.metrics.RecordBatchDuration(time.Since(bs.openedAt))
 	return nil
 }
 
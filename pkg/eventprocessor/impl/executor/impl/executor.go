@@ -5,26 +5,137 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog"
 	logger "github.com/rs/zerolog/log"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
 )
 
+// Config contains configuration attributes for an executor.
+type Config struct {
+	IsolationLevel     sql.IsolationLevel
+	MetricsRecorder    executor.MetricsRecorder
+	StatementCacheSize int
+	MaxBatchStatements int
+	RejectEmptyBatches bool
+	MaxDescriptionLen  int
+	AllowTableLocking  bool
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		IsolationLevel:     sql.LevelSerializable,
+		StatementCacheSize: 100,
+		MaxDescriptionLen:  1024,
+	}
+}
+
+// Option modifies a configuration attribute.
+type Option func(*Config) error
+
+// WithIsolationLevel sets the isolation level of the database transaction opened
+// for each block scope. Serializable is the default and the safest choice. Lower levels
+// might be acceptable in deployments where the database isn't shared with other writers.
+func WithIsolationLevel(level sql.IsolationLevel) Option {
+	return func(c *Config) error {
+		if level < sql.LevelDefault || level > sql.LevelLinearizable {
+			return fmt.Errorf("invalid isolation level %d", level)
+		}
+		c.IsolationLevel = level
+		return nil
+	}
+}
+
+// WithMetricsRecorder sets a recorder that gets notified about block scope durations,
+// executed write statements, and rejected statements. By default, nothing is recorded.
+func WithMetricsRecorder(recorder executor.MetricsRecorder) Option {
+	return func(c *Config) error {
+		c.MetricsRecorder = recorder
+		return nil
+	}
+}
+
+// WithStatementCacheSize sets the maximum number of prepared statements cached in each block scope
+// to execute repeated write queries. A size of zero disables the cache.
+func WithStatementCacheSize(size int) Option {
+	return func(c *Config) error {
+		if size < 0 {
+			return fmt.Errorf("statement cache size cannot be negative")
+		}
+		c.StatementCacheSize = size
+		return nil
+	}
+}
+
+// WithMaxBatchStatements limits the number of statements of a single write query. Longer
+// queries are rejected before executing any statement. Zero, the default, disables the limit.
+func WithMaxBatchStatements(maxStatements int) Option {
+	return func(c *Config) error {
+		if maxStatements < 0 {
+			return fmt.Errorf("max batch statements cannot be negative")
+		}
+		c.MaxBatchStatements = maxStatements
+		return nil
+	}
+}
+
+// WithRejectEmptyBatches indicates if a write query without statements fails with executor.ErrEmptyBatch.
+// By default, empty batches are skipped and considered successful.
+func WithRejectEmptyBatches(reject bool) Option {
+	return func(c *Config) error {
+		c.RejectEmptyBatches = reject
+		return nil
+	}
+}
+
+// WithMaxDescriptionLength limits the number of characters of table descriptions.
+// Zero disables the limit.
+func WithMaxDescriptionLength(length int) Option {
+	return func(c *Config) error {
+		if length < 0 {
+			return fmt.Errorf("max description length cannot be negative")
+		}
+		c.MaxDescriptionLen = length
+		return nil
+	}
+}
+
+// WithTableLocking indicates if the ownership of a table can be transferred to the zero address, which
+// locks the table since nobody can act as the zero address. By default, it's rejected.
+func WithTableLocking(enabled bool) Option {
+	return func(c *Config) error {
+		c.AllowTableLocking = enabled
+		return nil
+	}
+}
+
 // Executor executes chain events.
 type Executor struct {
 	log          zerolog.Logger
 	db           *sql.DB
 	parser       parsing.SQLValidator
 	acl          tableland.ACL
+	metrics      executor.MetricsRecorder
 	chBlockScope chan struct{}
 
-	chainID          tableland.ChainID
-	maxTableRowCount int
+	chainID            tableland.ChainID
+	maxTableRowCount   int
+	isolationLevel     sql.IsolationLevel
+	statementCacheSize int
+	maxBatchStatements int
+	rejectEmptyBatches bool
+	maxDescriptionLen  int
+	allowTableLocking  bool
 
 	closeOnce sync.Once
 	closed    chan struct{}
@@ -40,11 +151,23 @@ func NewExecutor(
 	parser parsing.SQLValidator,
 	maxTableRowCount int,
 	acl tableland.ACL,
+	opts ...Option,
 ) (*Executor, error) {
 	if maxTableRowCount < 0 {
 		return nil, fmt.Errorf("maximum table row count is negative")
 	}
 
+	config := DefaultConfig()
+	for _, op := range opts {
+		if err := op(config); err != nil {
+			return nil, fmt.Errorf("applying option: %s", err)
+		}
+	}
+	metrics := config.MetricsRecorder
+	if metrics == nil {
+		metrics = noopMetricsRecorder{}
+	}
+
 	log := logger.With().
 		Str("component", "executor").
 		Int64("chain_id", int64(chainID)).
@@ -54,10 +177,17 @@ func NewExecutor(
 		db:           db,
 		parser:       parser,
 		acl:          acl,
+		metrics:      metrics,
 		chBlockScope: make(chan struct{}, 1),
 
-		chainID:          chainID,
-		maxTableRowCount: maxTableRowCount,
+		chainID:            chainID,
+		maxTableRowCount:   maxTableRowCount,
+		isolationLevel:     config.IsolationLevel,
+		statementCacheSize: config.StatementCacheSize,
+		maxBatchStatements: config.MaxBatchStatements,
+		rejectEmptyBatches: config.RejectEmptyBatches,
+		maxDescriptionLen:  config.MaxDescriptionLen,
+		allowTableLocking:  config.AllowTableLocking,
 
 		closed: make(chan struct{}),
 	}
@@ -67,17 +197,19 @@ func NewExecutor(
 }
 
 // NewBlockScope starts a block scope to execute EVM transactions with events.
+// If there's an open block scope, it waits until it's closed or ctx is canceled.
+// If ctx carries a block time (see executor.ContextWithBlockTime), the block scope uses it.
 func (ex *Executor) NewBlockScope(ctx context.Context, newBlockNum int64) (executor.BlockScope, error) {
 	select {
 	case <-ex.chBlockScope:
 	case <-ex.closed:
 		return nil, fmt.Errorf("executor is closed")
-	default:
-		panic("parallel block scope detected, this must never happen")
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 	releaseBlockScope := func() { ex.chBlockScope <- struct{}{} }
 
-	txn, err := ex.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: false})
+	txn, err := ex.db.BeginTx(ctx, &sql.TxOptions{Isolation: ex.isolationLevel, ReadOnly: false})
 	if err != nil {
 		releaseBlockScope()
 		return nil, fmt.Errorf("opening db transaction: %s", err)
@@ -95,15 +227,91 @@ func (ex *Executor) NewBlockScope(ctx context.Context, newBlockNum int64) (execu
 	}
 
 	scopeVars := scopeVars{
-		ChainID:          ex.chainID,
-		MaxTableRowCount: ex.maxTableRowCount,
-		BlockNumber:      newBlockNum,
+		ChainID:            ex.chainID,
+		MaxTableRowCount:   ex.maxTableRowCount,
+		BlockNumber:        newBlockNum,
+		MaxBatchStatements: ex.maxBatchStatements,
+		RejectEmptyBatches: ex.rejectEmptyBatches,
+		MaxDescriptionLen:  ex.maxDescriptionLen,
+		AllowTableLocking:  ex.allowTableLocking,
 	}
-	bs := newBlockScope(txn, scopeVars, ex.parser, ex.acl, releaseBlockScope)
+	if blockTime, ok := executor.BlockTimeFromContext(ctx); ok {
+		scopeVars.BlockTime = blockTime
+	}
+	stmts := newStmtCache(txn, ex.statementCacheSize)
+	bs := newBlockScope(txn, stmts, scopeVars, ex.parser, ex.acl, ex.metrics, releaseBlockScope)
 
 	return bs, nil
 }
 
+// ImportRows inserts rows into a table if caller has insert privileges, outside of any block scope.
+// It's meant for operators loading data into the tables of their own node, so no chain event can
+// reach it. Imported rows aren't the result of executing chain events, so the state hash of the
+// node diverges from other validators unless they import the same rows.
+//
+// The row count limit is checked against the whole batch, and either all the rows are inserted or
+// none. Tables with a controller aren't supported. If the table doesn't exist, it returns an
+// *executor.ErrTableNotExist error. If there's an open block scope, it waits until it's closed.
+func (ex *Executor) ImportRows(
+	ctx context.Context,
+	id tables.TableID,
+	caller common.Address,
+	columns []string,
+	rows [][]interface{},
+) error {
+	select {
+	case <-ex.chBlockScope:
+	case <-ex.closed:
+		return fmt.Errorf("executor is closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { ex.chBlockScope <- struct{}{} }()
+
+	txn, err := ex.db.BeginTx(ctx, &sql.TxOptions{Isolation: ex.isolationLevel, ReadOnly: false})
+	if err != nil {
+		return fmt.Errorf("opening db transaction: %s", err)
+	}
+	defer func() {
+		_ = txn.Rollback()
+	}()
+	lastBlockNum, err := ex.getLastExecutedBlockNumber(ctx, txn)
+	if err != nil {
+		return fmt.Errorf("get last processed height: %s", err)
+	}
+
+	stmts := newStmtCache(txn, ex.statementCacheSize)
+	defer func() {
+		if err := stmts.Close(); err != nil {
+			ex.log.Warn().Err(err).Msg("closing cached statements")
+		}
+	}()
+	ts := &txnScope{
+		scopeVars: scopeVars{
+			ChainID:          ex.chainID,
+			MaxTableRowCount: ex.maxTableRowCount,
+			BlockNumber:      lastBlockNum,
+		},
+		parser:  ex.parser,
+		acl:     ex.acl,
+		metrics: ex.metrics,
+		log: logger.With().
+			Str("component", "txnscope").
+			Int64("chain_id", int64(ex.chainID)).
+			Logger(),
+		txn:   txn,
+		stmts: stmts,
+	}
+	if err := ts.bulkInsert(ctx, id, caller, columns, rows); err != nil {
+		return fmt.Errorf("importing rows: %w", err)
+	}
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit db txn: %s", err)
+	}
+
+	return nil
+}
+
 // GetLastExecutedBlockNumber returns the last block number that was successfully executed.
 func (ex *Executor) GetLastExecutedBlockNumber(ctx context.Context) (int64, error) {
 	txn, err := ex.db.Begin()
@@ -120,6 +328,75 @@ func (ex *Executor) GetLastExecutedBlockNumber(ctx context.Context) (int64, erro
 	return blockNumber, nil
 }
 
+// GetTableRowCount returns the current number of rows of a table. Changes of a block scope that
+// wasn't committed yet aren't counted.
+func (ex *Executor) GetTableRowCount(ctx context.Context, id tables.TableID) (int, error) {
+	txn, err := ex.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("opening txn: %s", err)
+	}
+	defer func() {
+		_ = txn.Rollback()
+	}()
+
+	prefix, err := getTablePrefixByTableID(ctx, txn, ex.chainID, id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, rowCount, err := getTablePrefixAndRowCountByTableID(
+		ctx, txn, ex.chainID, id, parsing.PhysicalTableName(prefix, ex.chainID, id))
+	if err != nil {
+		return 0, fmt.Errorf("get table row count: %w", err)
+	}
+	return rowCount, nil
+}
+
+// EstimateAffectedRows returns the number of rows of a table that match a WHERE clause. The clause
+// is validated as the WHERE of a write statement targeting the table before counting.
+func (ex *Executor) EstimateAffectedRows(ctx context.Context, id tables.TableID, whereClause string) (int, error) {
+	txn, err := ex.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("opening txn: %s", err)
+	}
+	defer func() {
+		_ = txn.Rollback()
+	}()
+
+	prefix, err := getTablePrefixByTableID(ctx, txn, ex.chainID, id)
+	if err != nil {
+		return 0, err
+	}
+	dbTableName := parsing.PhysicalTableName(prefix, ex.chainID, id)
+
+	query := fmt.Sprintf("SELECT count(*) FROM %s", dbTableName)
+	if strings.TrimSpace(whereClause) != "" {
+		stmts, err := ex.parser.ValidateMutatingQuery(
+			fmt.Sprintf("DELETE FROM %s WHERE %s", dbTableName, whereClause), ex.chainID)
+		if err != nil {
+			return 0, fmt.Errorf("validating where clause: %w", err)
+		}
+		if len(stmts) != 1 {
+			return 0, fmt.Errorf("the where clause must be a single expression")
+		}
+		writeStmt, ok := stmts[0].(parsing.WriteStmt)
+		if !ok {
+			return 0, fmt.Errorf("the where clause must be a single expression")
+		}
+		where, err := writeStmt.BuildPolicyClause(whereClause)
+		if err != nil {
+			return 0, fmt.Errorf("building where clause: %w", err)
+		}
+		query = fmt.Sprintf("%s WHERE %s", query, where)
+	}
+
+	var count int
+	if err := txn.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting matching rows: %s", err)
+	}
+	return count, nil
+}
+
 func (ex *Executor) getLastExecutedBlockNumber(ctx context.Context, txn *sql.Tx) (int64, error) {
 	r := txn.QueryRowContext(
 		ctx,
@@ -181,3 +458,27 @@ func isErrCausedByQuery(err error) (string, bool) {
 	}
 	return "", false
 }
+
+// sqliteErrorCode returns the receipt error code of an error detected by isErrCausedByQuery.
+func sqliteErrorCode(err error) string {
+	var sqlErr sqlite3.Error
+	if !errors.As(err, &sqlErr) {
+		return eventprocessor.ErrorCodeExecutionFailed
+	}
+	switch sqlErr.Code {
+	case sqlite3.ErrConstraint:
+		return eventprocessor.ErrorCodeConstraintViolation
+	case sqlite3.ErrMismatch:
+		return eventprocessor.ErrorCodeTypeMismatch
+	case sqlite3.ErrTooBig:
+		return eventprocessor.ErrorCodeValueTooBig
+	default:
+		return eventprocessor.ErrorCodeExecutionFailed
+	}
+}
+
+type noopMetricsRecorder struct{}
+
+func (noopMetricsRecorder) RecordBatchDuration(time.Duration)             {}
+func (noopMetricsRecorder) RecordStmtExecuted(tableland.Operation, int64) {}
+func (noopMetricsRecorder) RecordRejection(string)                        {}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	_ "github.com/mattn/go-sqlite3"
@@ -12,6 +13,7 @@ import (
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
 	"github.com/textileio/go-tableland/pkg/sqlstore/impl/system"
@@ -155,6 +157,239 @@ func TestMultiEventTxnBlock(t *testing.T) {
 	}
 }
 
+func TestNewBlockScopeCanceledContext(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, _ := newExecutor(t, 0)
+
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+
+	// While bs is open, a new block scope can't be acquired.
+	ctx2, cls := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cls()
+	_, err = ex.NewBlockScope(ctx2, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Once bs is released, a new block scope can be acquired.
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+	bs, err = ex.NewBlockScope(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, bs.Close())
+
+	require.NoError(t, ex.Close(ctx))
+}
+
+func TestIsolationLevel(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		ex, _ := newExecutor(t, 0)
+		require.Equal(t, sql.LevelSerializable, ex.isolationLevel)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+
+		ex, _ := newExecutor(t, 0, WithIsolationLevel(sql.LevelRepeatableRead))
+		require.Equal(t, sql.LevelRepeatableRead, ex.isolationLevel)
+
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := sql.Open("sqlite3", tests.Sqlite3URI(t))
+		require.NoError(t, err)
+		_, err = NewExecutor(1337, db, newParser(t, []string{}), 0, &aclMock{}, WithIsolationLevel(sql.IsolationLevel(42)))
+		require.Error(t, err)
+	})
+}
+
+func TestMetricsRecorder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	recorder := &metricsRecorderMock{}
+	ex, _ := newExecutorWithIntegerTable(t, 0, WithMetricsRecorder(recorder))
+	// Ignore the measurements from the block scope that created the table.
+	recorder.durations = nil
+
+	bs, err := ex.NewBlockScope(ctx, 1)
+	require.NoError(t, err)
+	assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	require.Len(t, recorder.durations, 1)
+	require.Greater(t, recorder.durations[0], time.Duration(0))
+	require.Equal(t, []executedStmt{{op: tableland.OpInsert, rowsAffected: 1}}, recorder.stmts)
+	require.Empty(t, recorder.rejections)
+}
+
+func TestGetTableRowCount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, _ := newExecutorWithIntegerTable(t, 0)
+	id, err := tables.NewTableID("100")
+	require.NoError(t, err)
+
+	count, err := ex.GetTableRowCount(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	for _, q := range []string{
+		"insert into foo_1337_100 values (1);insert into foo_1337_100 values (2)",
+		"insert into foo_1337_100 values (3)",
+		"delete from foo_1337_100 where zar = 2",
+	} {
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		assertExecTxnWithRunSQLEvents(t, bs, []string{q})
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+	}
+
+	count, err = ex.GetTableRowCount(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	missingID, err := tables.NewTableID("101")
+	require.NoError(t, err)
+	_, err = ex.GetTableRowCount(ctx, missingID)
+	var errTableNotExist *executor.ErrTableNotExist
+	require.ErrorAs(t, err, &errTableNotExist)
+	require.Equal(t, missingID, errTableNotExist.TableID)
+}
+
+func TestEstimateAffectedRows(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, dbURI := newExecutorWithIntegerTable(t, 0)
+	id, err := tables.NewTableID("100")
+	require.NoError(t, err)
+
+	bs, err := ex.NewBlockScope(ctx, 1)
+	require.NoError(t, err)
+	assertExecTxnWithRunSQLEvents(t, bs, []string{
+		"insert into foo_1337_100 values (1);insert into foo_1337_100 values (2);insert into foo_1337_100 values (3)",
+	})
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	estimate, err := ex.EstimateAffectedRows(ctx, id, "zar >= 2")
+	require.NoError(t, err)
+	require.Equal(t, 2, estimate)
+
+	estimate, err = ex.EstimateAffectedRows(ctx, id, "")
+	require.NoError(t, err)
+	require.Equal(t, 3, estimate)
+
+	// The estimate matches the rows the update actually affects.
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	res, err := db.ExecContext(ctx, "update foo_1337_100 set zar = zar * 10 where zar >= 2")
+	require.NoError(t, err)
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), affected)
+
+	_, err = ex.EstimateAffectedRows(ctx, id, "zar = 1; delete from foo_1337_100")
+	require.Error(t, err)
+	_, err = ex.EstimateAffectedRows(ctx, id, "zar in (select zar from registry)")
+	require.Error(t, err)
+
+	missingID, err := tables.NewTableID("101")
+	require.NoError(t, err)
+	_, err = ex.EstimateAffectedRows(ctx, missingID, "zar = 1")
+	var errTableNotExist *executor.ErrTableNotExist
+	require.ErrorAs(t, err, &errTableNotExist)
+}
+
+// TestReplayMixedCaseIdentifiers replays events that reference tables with casings the gateway
+// folds, and checks that executing them gives the same receipts and state as it always did.
+func TestReplayMixedCaseIdentifiers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dbURI := tests.Sqlite3URI(t)
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	parser := newParser(t, []string{"system_", "registry", "sqlite_"})
+	ex, err := NewExecutor(1337, db, parser, 0, &aclMock{})
+	require.NoError(t, err)
+	_, err = system.New(dbURI, tableland.ChainID(chainID))
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	createTable := func(id int64, stmt string) interface{} {
+		return &ethereum.ContractCreateTable{Owner: owner, TableId: big.NewInt(id), Statement: stmt}
+	}
+	runSQL := func(id int64, stmt string) interface{} {
+		return &ethereum.ContractRunSQL{
+			Caller:    owner,
+			IsOwner:   true,
+			TableId:   big.NewInt(id),
+			Statement: stmt,
+			Policy:    ethereum.ITablelandControllerPolicy{AllowInsert: true, AllowUpdate: true, AllowDelete: true},
+		}
+	}
+	str := func(s string) *string { return &s }
+
+	history := []struct {
+		event    interface{}
+		expError *string
+	}{
+		{event: createTable(100, "create table foo_1337 (a int)")},
+		{event: createTable(101, "create table System_foo_1337 (a int)")},
+		{event: createTable(102, "create table Registry_1337 (a int)")},
+		{event: runSQL(101, "insert into System_foo_1337_101 values (1)")},
+		{event: runSQL(102, "insert into Registry_1337_102 values (1)")},
+		{event: runSQL(100, "insert into FOO_1337_100 values (1)")},
+		{
+			event:    runSQL(100, "insert into foo_1337_100 values (2); insert into FOO_1337_100 values (3)"),
+			expError: str("parsing query: queries are referencing two distinct tables: foo_1337_100 FOO_1337_100"),
+		},
+	}
+	for i, h := range history {
+		bs, err := ex.NewBlockScope(ctx, int64(i))
+		require.NoError(t, err)
+		var txnHash common.Hash
+		txnHash[0] = byte(i + 1)
+		res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{TxnHash: txnHash, Events: []interface{}{h.event}})
+		require.NoError(t, err)
+		require.Equal(t, h.expError, res.Error, "event %d", i)
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+	}
+
+	require.Equal(t, 1, tableReadInteger(t, dbURI, "select count(*) from System_foo_1337_101"))
+	require.Equal(t, 1, tableReadInteger(t, dbURI, "select count(*) from Registry_1337_102"))
+	require.Equal(t, 1, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+
+	bs, err := ex.NewBlockScope(ctx, int64(len(history)))
+	require.NoError(t, err)
+	hash, err := bs.StateHash(ctx, tableland.ChainID(chainID))
+	require.NoError(t, err)
+	require.NoError(t, bs.Close())
+	require.Equal(t, "845641eea69393874a066ef61cf0e30fef67b9ba", hash.Hash)
+
+	require.NoError(t, ex.Close(ctx))
+}
+
 func tableReadInteger(t *testing.T, dbURI string, query string) int {
 	t.Helper()
 
@@ -201,7 +436,7 @@ func existsTableWithName(t *testing.T, dbURI string, tableName string) bool {
 	return true
 }
 
-func newExecutor(t *testing.T, rowsLimit int) (*Executor, string) {
+func newExecutor(t testing.TB, rowsLimit int, opts ...Option) (*Executor, string) {
 	t.Helper()
 
 	dbURI := tests.Sqlite3URI(t)
@@ -210,7 +445,7 @@ func newExecutor(t *testing.T, rowsLimit int) (*Executor, string) {
 	db, err := sql.Open("sqlite3", dbURI)
 	require.NoError(t, err)
 	db.SetMaxOpenConns(1)
-	exec, err := NewExecutor(1337, db, parser, rowsLimit, &aclMock{})
+	exec, err := NewExecutor(1337, db, parser, rowsLimit, &aclMock{}, opts...)
 	require.NoError(t, err)
 
 	// Boostrap system store to run the db migrations.
@@ -219,18 +454,18 @@ func newExecutor(t *testing.T, rowsLimit int) (*Executor, string) {
 	return exec, dbURI
 }
 
-func newExecutorWithStringTable(t *testing.T, rowsLimit int) (*Executor, string) {
-	return newExecutorWithTable(t, rowsLimit, "create table foo_1337 (zar text)")
+func newExecutorWithStringTable(t *testing.T, rowsLimit int, opts ...Option) (*Executor, string) {
+	return newExecutorWithTable(t, rowsLimit, "create table foo_1337 (zar text)", opts...)
 }
 
-func newExecutorWithIntegerTable(t *testing.T, rowsLimit int) (*Executor, string) { //nolint
-	return newExecutorWithTable(t, rowsLimit, "create table foo_1337 (zar int)")
+func newExecutorWithIntegerTable(t testing.TB, rowsLimit int, opts ...Option) (*Executor, string) { //nolint
+	return newExecutorWithTable(t, rowsLimit, "create table foo_1337 (zar int)", opts...)
 }
 
-func newExecutorWithTable(t *testing.T, rowsLimit int, createStmt string) (*Executor, string) {
+func newExecutorWithTable(t testing.TB, rowsLimit int, createStmt string, opts ...Option) (*Executor, string) {
 	t.Helper()
 
-	ex, dbURI := newExecutor(t, rowsLimit)
+	ex, dbURI := newExecutor(t, rowsLimit, opts...)
 	ctx := context.Background()
 
 	ibs, err := ex.NewBlockScope(ctx, 0)
@@ -271,7 +506,7 @@ func mustGrantStmt(t *testing.T, q string) parsing.MutatingStmt {
 	return wss[0]
 }
 
-func newParser(t *testing.T, prefixes []string) parsing.SQLValidator {
+func newParser(t testing.TB, prefixes []string) parsing.SQLValidator {
 	t.Helper()
 	p, err := parserimpl.New(prefixes)
 	require.NoError(t, err)
@@ -289,3 +524,26 @@ func (acl *aclMock) CheckPrivileges(
 ) (bool, error) {
 	return true, nil
 }
+
+type executedStmt struct {
+	op           tableland.Operation
+	rowsAffected int64
+}
+
+type metricsRecorderMock struct {
+	durations  []time.Duration
+	stmts      []executedStmt
+	rejections []string
+}
+
+func (m *metricsRecorderMock) RecordBatchDuration(d time.Duration) {
+	m.durations = append(m.durations, d)
+}
+
+func (m *metricsRecorderMock) RecordStmtExecuted(op tableland.Operation, rowsAffected int64) {
+	m.stmts = append(m.stmts, executedStmt{op: op, rowsAffected: rowsAffected})
+}
+
+func (m *metricsRecorderMock) RecordRejection(code string) {
+	m.rejections = append(m.rejections, code)
+}
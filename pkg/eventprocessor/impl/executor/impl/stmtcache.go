@@ -0,0 +1,90 @@
+package impl
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// stmtCache is an LRU cache of prepared statements bound to a block scope database transaction.
+// Write queries that are repeated with the same canonical query (e.g: the same INSERT executed by
+// many transactions in a block) are prepared once and reused, avoiding parsing and planning them
+// again. Literal values are part of the canonical query, so statements that only differ in their
+// values are prepared separately.
+type stmtCache struct {
+	txn  *sql.Tx
+	size int
+
+	ll    *list.List
+	items map[string]*list.Element
+
+	hits int
+}
+
+type stmtCacheEntry struct {
+	query string
+	stmt  *sql.Stmt
+}
+
+func newStmtCache(txn *sql.Tx, size int) *stmtCache {
+	return &stmtCache{
+		txn:   txn,
+		size:  size,
+		ll:    list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+// ExecContext executes the resolved query of a write statement using a cached prepared statement,
+// preparing it if necessary. Statements are cached by their canonical query. Custom functions
+// (e.g: txn_hash()) resolve to different values in each txn, so statements whose resolved query
+// differs from the canonical one are executed directly in the transaction, as they are if the
+// cache is disabled.
+func (c *stmtCache) ExecContext(ctx context.Context, canonicalQuery string, query string) (sql.Result, error) {
+	if c.size <= 0 || query != canonicalQuery {
+		return c.txn.ExecContext(ctx, query)
+	}
+	stmt, err := c.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.ExecContext(ctx)
+}
+
+func (c *stmtCache) get(ctx context.Context, query string) (*sql.Stmt, error) {
+	if e, ok := c.items[query]; ok {
+		c.hits++
+		c.ll.MoveToFront(e)
+		return e.Value.(*stmtCacheEntry).stmt, nil
+	}
+
+	stmt, err := c.txn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.items[query] = c.ll.PushFront(&stmtCacheEntry{query: query, stmt: stmt})
+
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		entry := c.ll.Remove(oldest).(*stmtCacheEntry)
+		delete(c.items, entry.query)
+		if err := entry.stmt.Close(); err != nil {
+			return nil, fmt.Errorf("closing evicted statement: %s", err)
+		}
+	}
+
+	return stmt, nil
+}
+
+// Close closes all the cached prepared statements.
+func (c *stmtCache) Close() error {
+	for e := c.ll.Front(); e != nil; e = e.Next() {
+		if err := e.Value.(*stmtCacheEntry).stmt.Close(); err != nil {
+			return fmt.Errorf("closing statement: %s", err)
+		}
+	}
+	c.ll.Init()
+	c.items = map[string]*list.Element{}
+	return nil
+}
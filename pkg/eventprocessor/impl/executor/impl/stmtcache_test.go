@@ -0,0 +1,128 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+		ibs, err := ex.NewBlockScope(ctx, 1)
+		require.NoError(t, err)
+		bs := ibs.(*blockScope)
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (2)"})
+		require.Equal(t, 1, bs.stmts.hits)
+		require.Equal(t, 2, bs.stmts.ll.Len())
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 3, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+	})
+
+	t.Run("equivalent statements", func(t *testing.T) {
+		t.Parallel()
+
+		ex, _ := newExecutorWithIntegerTable(t, 0)
+
+		ibs, err := ex.NewBlockScope(ctx, 1)
+		require.NoError(t, err)
+		bs := ibs.(*blockScope)
+		// Queries are cached by their canonical form, so casing and whitespace don't matter.
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"INSERT INTO foo_1337_100\n  VALUES(1);"})
+		require.Equal(t, 1, bs.stmts.hits)
+		require.Equal(t, 1, bs.stmts.ll.Len())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+	})
+
+	t.Run("custom functions", func(t *testing.T) {
+		t.Parallel()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+		ibs, err := ex.NewBlockScope(ctx, 1)
+		require.NoError(t, err)
+		bs := ibs.(*blockScope)
+		// Resolved custom functions aren't part of the canonical query, so it isn't cached.
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (block_num())"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (block_num())"})
+		require.Equal(t, 0, bs.stmts.hits)
+		require.Equal(t, 0, bs.stmts.ll.Len())
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 2, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100 where zar = 1"))
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		t.Parallel()
+
+		ex, _ := newExecutorWithIntegerTable(t, 0, WithStatementCacheSize(1))
+
+		ibs, err := ex.NewBlockScope(ctx, 1)
+		require.NoError(t, err)
+		bs := ibs.(*blockScope)
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (2)"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		require.Equal(t, 0, bs.stmts.hits)
+		require.Equal(t, 1, bs.stmts.ll.Len())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 0, WithStatementCacheSize(0))
+
+		ibs, err := ex.NewBlockScope(ctx, 1)
+		require.NoError(t, err)
+		bs := ibs.(*blockScope)
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		assertExecTxnWithRunSQLEvents(t, bs, []string{"insert into foo_1337_100 values (1)"})
+		require.Equal(t, 0, bs.stmts.hits)
+		require.Equal(t, 0, bs.stmts.ll.Len())
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 2, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+	})
+}
+
+func BenchmarkRepeatedInserts(b *testing.B) {
+	for _, size := range []int{0, 100} {
+		b.Run(fmt.Sprintf("cache size %d", size), func(b *testing.B) {
+			ctx := context.Background()
+			ex, _ := newExecutorWithIntegerTable(b, 0, WithStatementCacheSize(size))
+
+			bs, err := ex.NewBlockScope(ctx, 1)
+			require.NoError(b, err)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, res, err := execTxnWithRunSQLEvents(b, bs, []string{"insert into foo_1337_100 values (1)"})
+				require.NoError(b, err)
+				require.Nil(b, res.Error)
+			}
+			b.StopTimer()
+			require.NoError(b, bs.Close())
+			require.NoError(b, ex.Close(ctx))
+		})
+	}
+}
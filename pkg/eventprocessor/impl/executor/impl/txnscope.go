@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
 	"github.com/tablelandnetwork/sqlparser"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
@@ -25,6 +28,9 @@ var tableIDIsEmpty = "table id is empty"
 type errQueryExecution struct {
 	Code string
 	Msg  string
+	// ErrorCode is the eventprocessor.ErrorCode* constant reported in the receipt. Code is part
+	// of the receipt error message, so it can't change without changing the state hash.
+	ErrorCode string
 }
 
 // Error returns a string representation of the query execution error.
@@ -39,14 +45,18 @@ type txnScope struct {
 	statementResolver sqlparser.WriteStatementResolver
 
 	acl       tableland.ACL
+	metrics   executor.MetricsRecorder
 	scopeVars scopeVars
 
-	txn *sql.Tx
+	txn     *sql.Tx
+	txnHash common.Hash
+	stmts   *stmtCache
 }
 
 type eventExecutionResult struct {
-	TableID *tables.TableID
-	Error   *string
+	TableID   *tables.TableID
+	Error     *string
+	ErrorCode string
 }
 
 func (ts *txnScope) executeTxnEvents(
@@ -104,6 +114,7 @@ func (ts *txnScope) executeTxnEvents(
 			return executor.TxnExecutionResult{
 				TableID:       res.TableID,
 				Error:         res.Error,
+				ErrorCode:     res.ErrorCode,
 				ErrorEventIdx: &idx,
 			}, nil
 		}
@@ -111,3 +122,27 @@ func (ts *txnScope) executeTxnEvents(
 
 	return executor.TxnExecutionResult{TableID: res.TableID}, nil
 }
+
+// checkTableOwner checks that the table exists and is owned by owner, and returns its prefix.
+func (ts *txnScope) checkTableOwner(ctx context.Context, id tables.TableID, owner common.Address) (string, error) {
+	r := ts.txn.QueryRowContext(ctx,
+		"SELECT controller, prefix FROM registry WHERE chain_id=?1 AND id=?2",
+		ts.scopeVars.ChainID,
+		id.String())
+	var controller, prefix string
+	if err := r.Scan(&controller, &prefix); err != nil {
+		if err == sql.ErrNoRows {
+			return "", &executor.ErrTableNotExist{TableID: id}
+		}
+		return "", fmt.Errorf("table owner lookup: %s", err)
+	}
+	if !strings.EqualFold(controller, owner.Hex()) {
+		return "", &errQueryExecution{
+			Code:      "ACL_NOT_OWNER",
+			Msg:       "non owner cannot change the table",
+			ErrorCode: eventprocessor.ErrorCodeACLDenied,
+		}
+	}
+
+	return prefix, nil
+}
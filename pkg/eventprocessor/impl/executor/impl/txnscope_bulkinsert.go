@@ -0,0 +1,154 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+// bulkInsert inserts rows into a table with a single prepared statement, after checking the insert
+// privileges of caller and that the table row count limit holds for the whole batch. Values are bound
+// as query parameters, so they never go through the SQL parser. The whole batch is recorded as a
+// single audit entry.
+func (ts *txnScope) bulkInsert(
+	ctx context.Context,
+	id tables.TableID,
+	caller common.Address,
+	columns []string,
+	rows [][]interface{},
+) error {
+	if len(columns) == 0 {
+		return &errQueryExecution{
+			Code:      "BULK_INSERT",
+			Msg:       "no columns were provided",
+			ErrorCode: eventprocessor.ErrorCodeInvalidSyntax,
+		}
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return &errQueryExecution{
+				Code:      "BULK_INSERT",
+				Msg:       fmt.Sprintf("row %d has %d values but %d columns were provided", i, len(row), len(columns)),
+				ErrorCode: eventprocessor.ErrorCodeInvalidSyntax,
+			}
+		}
+	}
+
+	prefix, err := getTablePrefixByTableID(ctx, ts.txn, ts.scopeVars.ChainID, id)
+	if err != nil {
+		return err
+	}
+	dbTableName := parsing.PhysicalTableName(prefix, ts.scopeVars.ChainID, id)
+
+	// Controller policies are evaluated per statement by the controller contract, so they
+	// can't be applied to a batch of values.
+	controller, err := ts.getController(ctx, id)
+	if err != nil {
+		return fmt.Errorf("checking controller is set: %w", err)
+	}
+	if controller != "" {
+		return &errQueryExecution{
+			Code:      "POLICY",
+			Msg:       "bulk insert is not allowed on tables with a controller",
+			ErrorCode: eventprocessor.ErrorCodePolicyDenied,
+		}
+	}
+	ok, err := ts.acl.CheckPrivileges(ctx, ts.txn, caller, id, tableland.OpInsert)
+	if err != nil {
+		return fmt.Errorf("error checking acl: %s", err)
+	}
+	if !ok {
+		return &errQueryExecution{
+			Code:      "ACL",
+			Msg:       "not enough privileges",
+			ErrorCode: eventprocessor.ErrorCodeACLDenied,
+		}
+	}
+
+	if err := ts.checkBulkInsertColumns(ctx, dbTableName, columns); err != nil {
+		return err
+	}
+
+	var rowCount int
+	if err := ts.txn.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT count(*) FROM %s", dbTableName)).Scan(&rowCount); err != nil {
+		return fmt.Errorf("table row count: %s", err)
+	}
+	if err := ts.checkRowCountLimit(int64(len(rows)), true, rowCount); err != nil {
+		return err
+	}
+
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = `"` + c + `"`
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	stmt, err := ts.txn.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)", dbTableName, strings.Join(quoted, ","), placeholders))
+	if err != nil {
+		return fmt.Errorf("preparing insert: %s", err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			ts.log.Warn().Err(err).Msg("closing bulk insert statement")
+		}
+	}()
+	for i, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			if code, ok := isErrCausedByQuery(err); ok {
+				return &errQueryExecution{
+					Code:      "SQLITE_" + code,
+					Msg:       fmt.Sprintf("inserting row %d: %s", i, err),
+					ErrorCode: sqliteErrorCode(err),
+				}
+			}
+			return fmt.Errorf("inserting row %d: %s", i, err)
+		}
+	}
+	ts.metrics.RecordStmtExecuted(tableland.OpInsert, int64(len(rows)))
+
+	// Imported rows aren't part of a chain txn, so their audit entry has a zero txn hash.
+	return ts.insertAuditEntry(ctx, id, tableland.OpInsert, caller, int64(len(rows)))
+}
+
+// checkBulkInsertColumns checks that all the columns exist in the table, since column names
+// are interpolated in the insert statement.
+func (ts *txnScope) checkBulkInsertColumns(ctx context.Context, dbTableName string, columns []string) error {
+	rows, err := ts.txn.QueryContext(ctx, "SELECT name FROM pragma_table_info(?1)", dbTableName)
+	if err != nil {
+		return fmt.Errorf("table columns lookup: %s", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			ts.log.Warn().Err(err).Msg("closing rows")
+		}
+	}()
+	existing := map[string]struct{}{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("scanning column name: %s", err)
+		}
+		existing[strings.ToLower(name)] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating table columns: %s", err)
+	}
+
+	for _, c := range columns {
+		if _, ok := existing[strings.ToLower(c)]; !ok {
+			return &errQueryExecution{
+				Code:      "BULK_INSERT",
+				Msg:       fmt.Sprintf("column %s doesn't exist", c),
+				ErrorCode: eventprocessor.ErrorCodeInvalidSyntax,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestImportRows(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	id, err := tables.NewTableID("100")
+	require.NoError(t, err)
+	caller := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	newRows := func(n int) [][]interface{} {
+		rows := make([][]interface{}, n)
+		for i := range rows {
+			rows[i] = []interface{}{i + 1}
+		}
+		return rows
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+		require.NoError(t, ex.ImportRows(ctx, id, caller, []string{"zar"}, newRows(10000)))
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 10000, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+		require.Equal(t, 10000*10001/2, tableReadInteger(t, dbURI, "select sum(zar) from foo_1337_100"))
+
+		// The whole batch is audited as a single insert.
+		require.Equal(t, 1, tableReadInteger(t, dbURI, "select count(*) from system_audit"))
+		require.Equal(t, 10000, tableReadInteger(t, dbURI,
+			"select rows_affected from system_audit where table_id = 100 and operation = 'OpInsert'"))
+	})
+
+	t.Run("row count limit", func(t *testing.T) {
+		t.Parallel()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 10000)
+
+		err := ex.ImportRows(ctx, id, caller, []string{"zar"}, newRows(10001))
+		var dbErr *errQueryExecution
+		require.ErrorAs(t, err, &dbErr)
+		require.Equal(t, "ROW_COUNT_LIMIT", dbErr.Code)
+		require.NoError(t, ex.ImportRows(ctx, id, caller, []string{"zar"}, newRows(10000)))
+		err = ex.ImportRows(ctx, id, caller, []string{"zar"}, newRows(1))
+		require.ErrorAs(t, err, &dbErr)
+		require.Equal(t, "ROW_COUNT_LIMIT", dbErr.Code)
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 10000, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+	})
+
+	t.Run("invalid columns", func(t *testing.T) {
+		t.Parallel()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+		var dbErr *errQueryExecution
+		err := ex.ImportRows(ctx, id, caller, []string{"nope"}, newRows(1))
+		require.ErrorAs(t, err, &dbErr)
+		require.Equal(t, "BULK_INSERT", dbErr.Code)
+		err = ex.ImportRows(ctx, id, caller, []string{"zar"}, [][]interface{}{{1}, {2, 3}})
+		require.ErrorAs(t, err, &dbErr)
+		require.Equal(t, "BULK_INSERT", dbErr.Code)
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 0, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+		require.Equal(t, 0, tableReadInteger(t, dbURI, "select count(*) from system_audit"))
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ex, _ := newExecutorWithIntegerTable(t, 0)
+
+		missing, err := tables.NewTableID("101")
+		require.NoError(t, err)
+		err = ex.ImportRows(ctx, missing, caller, []string{"zar"}, newRows(1))
+		var notExistErr *executor.ErrTableNotExist
+		require.ErrorAs(t, err, &notExistErr)
+		require.NoError(t, ex.Close(ctx))
+	})
+}
@@ -2,10 +2,13 @@ package impl
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
@@ -18,11 +21,11 @@ func (ts *txnScope) executeCreateTableEvent(
 	createStmt, err := ts.parser.ValidateCreateTable(e.Statement, ts.scopeVars.ChainID)
 	if err != nil {
 		err := fmt.Sprintf("query validation: %s", err)
-		return eventExecutionResult{Error: &err}, nil
+		return eventExecutionResult{Error: &err, ErrorCode: eventprocessor.ErrorCodeInvalidSyntax}, nil
 	}
 
 	if e.TableId == nil {
-		return eventExecutionResult{Error: &tableIDIsEmpty}, nil
+		return eventExecutionResult{Error: &tableIDIsEmpty, ErrorCode: eventprocessor.ErrorCodeMissingTableID}, nil
 	}
 	tableID := tables.TableID(*e.TableId)
 
@@ -30,7 +33,7 @@ func (ts *txnScope) executeCreateTableEvent(
 		var dbErr *errQueryExecution
 		if errors.As(err, &dbErr) {
 			err := fmt.Sprintf("table creation execution failed (code: %s, msg: %s)", dbErr.Code, dbErr.Msg)
-			return eventExecutionResult{Error: &err}, nil
+			return eventExecutionResult{Error: &err, ErrorCode: dbErr.ErrorCode}, nil
 		}
 		return eventExecutionResult{}, fmt.Errorf("executing table creation: %s", err)
 	}
@@ -42,12 +45,39 @@ func (ts *txnScope) executeCreateTableEvent(
 // - Registers the table in the system-wide table registry.
 // - Executes the CREATE statement.
 // - Add default privileges in the system_acl table.
+//
+// Replaying the event of an already created table is a noop, as long as the structure matches
+// the registered one. Otherwise, the table ID is in conflict and the creation fails.
 func (ts *txnScope) insertTable(
 	ctx context.Context,
 	id tables.TableID,
 	controller string,
 	createStmt parsing.CreateStmt,
 ) error {
+	var existingStructure string
+	err := ts.txn.QueryRowContext(ctx,
+		"SELECT structure FROM registry WHERE chain_id=?1 AND id=?2",
+		ts.scopeVars.ChainID,
+		id.String()).Scan(&existingStructure)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("looking up registered table: %s", err)
+	}
+	if err == nil {
+		if existingStructure == createStmt.GetStructureHash() {
+			return nil
+		}
+		conflictErr := &executor.ErrTableIDConflict{
+			TableID:           id,
+			Structure:         createStmt.GetStructureHash(),
+			ExistingStructure: existingStructure,
+		}
+		return &errQueryExecution{
+			Code:      "TABLE_ID_CONFLICT",
+			Msg:       conflictErr.Error(),
+			ErrorCode: eventprocessor.ErrorCodeTableIDConflict,
+		}
+	}
+
 	if _, err := ts.txn.ExecContext(ctx,
 		`INSERT INTO registry ("chain_id", "id","controller","prefix","structure") 
 		  	 VALUES (?1,?2,?3,?4,?5);`,
@@ -77,8 +107,9 @@ func (ts *txnScope) insertTable(
 	if _, err := ts.txn.ExecContext(ctx, query); err != nil {
 		if code, ok := isErrCausedByQuery(err); ok {
 			return &errQueryExecution{
-				Code: "SQLITE_" + code,
-				Msg:  err.Error(),
+				Code:      "SQLITE_" + code,
+				Msg:       err.Error(),
+				ErrorCode: sqliteErrorCode(err),
 			}
 		}
 		return fmt.Errorf("exec CREATE statement: %s", err)
@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"database/sql"
 	"math/big"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/parsing"
 	"github.com/textileio/go-tableland/pkg/sqlstore/impl/system"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
@@ -51,6 +53,108 @@ func TestCreateTable(t *testing.T) {
 		ok := existsTableWithName(t, dbURI, "bar_1337_100")
 		require.True(t, ok)
 	})
+
+	t.Run("physical table name", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		ex, dbURI := newExecutor(t, 0)
+
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		assertExecTxnWithCreateTable(t, bs, 100, "0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF", "create table Bar_1337 (zar text)") //nolint
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		// The name derived from the registry entry must match the table created by the statement.
+		systemStore, err := system.New(dbURI, tableland.ChainID(chainID))
+		require.NoError(t, err)
+		tableID, _ := tables.NewTableID("100")
+		table, err := systemStore.GetTable(ctx, tableID)
+		require.NoError(t, err)
+
+		db, err := sql.Open("sqlite3", dbURI)
+		require.NoError(t, err)
+		tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, tx.Rollback()) }()
+
+		dbTableName := parsing.PhysicalTableName(table.Prefix, table.ChainID, table.ID)
+		require.Equal(t, "Bar_1337_100", dbTableName)
+		prefix, rowCount, err := getTablePrefixAndRowCountByTableID(ctx, tx, table.ChainID, table.ID, dbTableName)
+		require.NoError(t, err)
+		require.Equal(t, "Bar", prefix)
+		require.Equal(t, 0, rowCount)
+	})
+
+	t.Run("replayed event", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		ex, dbURI := newExecutor(t, 0)
+		owner := "0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"
+
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		assertExecTxnWithCreateTable(t, bs, 100, owner, "create table bar_1337 (zar text)")
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+
+		bs, err = ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(100),
+				Owner:     common.HexToAddress(owner),
+				Statement: "create table bar_1337 (zar text)",
+			},
+		}})
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		require.NotNil(t, res.TableID)
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 1, tableReadInteger(t, dbURI, "select count(*) from registry where id = 100"))
+		require.Equal(t, 1, tableReadInteger(t, dbURI, "select count(*) from system_acl where table_id = 100"))
+	})
+
+	t.Run("table id conflict", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		ex, dbURI := newExecutor(t, 0)
+		owner := "0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"
+
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		assertExecTxnWithCreateTable(t, bs, 100, owner, "create table bar_1337 (zar text)")
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+
+		bs, err = ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(100),
+				Owner:     common.HexToAddress(owner),
+				Statement: "create table bar_1337 (zar int)",
+			},
+		}})
+		require.NoError(t, err)
+		require.NotNil(t, res.Error)
+		require.Contains(t, *res.Error, "TABLE_ID_CONFLICT")
+		require.Nil(t, res.TableID)
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		// echo -n zar:TEXT | shasum -a 256
+		require.Equal(t,
+			"7ec5320c16e06e90af5e7131ff0c80d4b0a08fcd62aa6e38ad8d6843bc480d09",
+			tableReadString(t, dbURI, "select structure from registry where id = 100"))
+	})
 }
 
 func assertExecTxnWithCreateTable(t *testing.T, bs executor.BlockScope, tableID int, owner string, stmt string) {
@@ -0,0 +1,58 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+// dropTable removes a table from Tableland:
+// - Checks that the table exists and is owned by owner.
+// - Removes the table entries in the system_acl and system_controller tables.
+// - Removes the table from the system-wide table registry.
+// - Executes the DROP statement.
+func (ts *txnScope) dropTable(
+	ctx context.Context,
+	id tables.TableID,
+	owner common.Address,
+) error {
+	prefix, err := ts.checkTableOwner(ctx, id, owner)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ts.txn.ExecContext(ctx,
+		"DELETE FROM system_acl WHERE chain_id=?1 AND table_id=?2",
+		ts.scopeVars.ChainID,
+		id.String()); err != nil {
+		return fmt.Errorf("deleting table entries from system acl: %s", err)
+	}
+	if _, err := ts.txn.ExecContext(ctx,
+		"DELETE FROM system_controller WHERE chain_id=?1 AND table_id=?2",
+		ts.scopeVars.ChainID,
+		id.String()); err != nil {
+		return fmt.Errorf("deleting table controller: %s", err)
+	}
+	if _, err := ts.txn.ExecContext(ctx,
+		"DELETE FROM system_table_descriptions WHERE chain_id=?1 AND table_id=?2",
+		ts.scopeVars.ChainID,
+		id.String()); err != nil {
+		return fmt.Errorf("deleting table description: %s", err)
+	}
+	if _, err := ts.txn.ExecContext(ctx,
+		"DELETE FROM registry WHERE chain_id=?1 AND id=?2",
+		ts.scopeVars.ChainID,
+		id.String()); err != nil {
+		return fmt.Errorf("deleting table from system-wide registry: %s", err)
+	}
+
+	dbTableName := parsing.PhysicalTableName(prefix, ts.scopeVars.ChainID, id)
+	if _, err := ts.txn.ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", dbTableName)); err != nil {
+		return fmt.Errorf("exec DROP statement: %s", err)
+	}
+
+	return nil
+}
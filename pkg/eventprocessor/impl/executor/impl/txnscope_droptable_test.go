@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestDropTable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	id, err := tables.NewTableID("100")
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+
+	tests := []struct {
+		name    string
+		caller  common.Address
+		dropped bool // the table is dropped before the tested drop
+
+		assertErr   func(t *testing.T, err error)
+		tableExists bool
+	}{
+		{
+			name:   "owner",
+			caller: owner,
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			tableExists: false,
+		},
+		{
+			name:   "non-owner",
+			caller: common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2"),
+			assertErr: func(t *testing.T, err error) {
+				var dbErr *errQueryExecution
+				require.ErrorAs(t, err, &dbErr)
+				require.Equal(t, "ACL_NOT_OWNER", dbErr.Code)
+			},
+			tableExists: true,
+		},
+		{
+			name:    "not exist",
+			caller:  owner,
+			dropped: true,
+			assertErr: func(t *testing.T, err error) {
+				var notExistErr *executor.ErrTableNotExist
+				require.ErrorAs(t, err, &notExistErr)
+				require.Equal(t, id.String(), notExistErr.TableID.String())
+			},
+			tableExists: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+			bs, err := ex.NewBlockScope(ctx, 1)
+			require.NoError(t, err)
+			if tc.dropped {
+				require.NoError(t, bs.DropTable(ctx, id, owner))
+			}
+			tc.assertErr(t, bs.DropTable(ctx, id, tc.caller))
+			require.NoError(t, bs.Commit())
+			require.NoError(t, bs.Close())
+			require.NoError(t, ex.Close(ctx))
+
+			expectedCount := 0
+			if tc.tableExists {
+				expectedCount = 1
+			}
+			require.Equal(t, tc.tableExists, existsTableWithName(t, dbURI, "foo_1337_100"))
+			require.Equal(t, expectedCount, tableReadInteger(t, dbURI,
+				fmt.Sprintf("select count(1) from registry where id = 100 and chain_id = %d", chainID)))
+			require.Equal(t, expectedCount, tableReadInteger(t, dbURI,
+				fmt.Sprintf("select count(1) from system_acl where table_id = 100 and chain_id = %d", chainID)))
+		})
+	}
+}
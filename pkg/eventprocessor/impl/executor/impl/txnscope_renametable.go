@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+// renameTable changes the prefix of a table:
+// - Checks that the table exists and is owned by owner.
+// - Checks that the new prefix follows the same rules as a prefix in a CREATE TABLE statement.
+// - Renames the physical table, since its name embeds the prefix.
+// - Updates the prefix in the system-wide table registry.
+// The structure hash only depends on the columns, so it doesn't change.
+func (ts *txnScope) renameTable(
+	ctx context.Context,
+	id tables.TableID,
+	owner common.Address,
+	newPrefix string,
+) error {
+	prefix, err := ts.checkTableOwner(ctx, id, owner)
+	if err != nil {
+		return err
+	}
+
+	// The parser owns the naming rules, so the prefix is checked by validating
+	// a CREATE TABLE statement that uses it.
+	createStmt, err := ts.parser.ValidateCreateTable(
+		fmt.Sprintf("CREATE TABLE %s_%d (a text)", newPrefix, ts.scopeVars.ChainID), ts.scopeVars.ChainID)
+	if err != nil || createStmt.GetPrefix() != newPrefix {
+		return &executor.ErrInvalidTablePrefix{Prefix: newPrefix}
+	}
+	if newPrefix == prefix {
+		return nil
+	}
+
+	oldName := parsing.PhysicalTableName(prefix, ts.scopeVars.ChainID, id)
+	newName := parsing.PhysicalTableName(newPrefix, ts.scopeVars.ChainID, id)
+	if _, err := ts.txn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", oldName, newName)); err != nil {
+		return fmt.Errorf("exec ALTER TABLE statement: %s", err)
+	}
+	if _, err := ts.txn.ExecContext(ctx,
+		"UPDATE registry SET prefix=?1 WHERE chain_id=?2 AND id=?3",
+		newPrefix,
+		ts.scopeVars.ChainID,
+		id.String()); err != nil {
+		return fmt.Errorf("updating table prefix in system-wide registry: %s", err)
+	}
+
+	return nil
+}
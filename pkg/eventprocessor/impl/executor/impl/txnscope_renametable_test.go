@@ -0,0 +1,126 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestRenameTable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	structureQuery := fmt.Sprintf("select structure from registry where id = 100 and chain_id = %d", chainID)
+	prefixQuery := fmt.Sprintf("select prefix from registry where id = 100 and chain_id = %d", chainID)
+	assertInvalidPrefix := func(prefix string) func(t *testing.T, err error) {
+		return func(t *testing.T, err error) {
+			var prefixErr *executor.ErrInvalidTablePrefix
+			require.ErrorAs(t, err, &prefixErr)
+			require.Equal(t, prefix, prefixErr.Prefix)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		tableID   string
+		caller    common.Address
+		newPrefix string
+
+		assertErr      func(t *testing.T, err error)
+		expectedPrefix string
+	}{
+		{
+			name:      "owner",
+			tableID:   "100",
+			caller:    owner,
+			newPrefix: "bar",
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			expectedPrefix: "bar",
+		},
+		{
+			name:      "non-owner",
+			tableID:   "100",
+			caller:    common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2"),
+			newPrefix: "bar",
+			assertErr: func(t *testing.T, err error) {
+				var dbErr *errQueryExecution
+				require.ErrorAs(t, err, &dbErr)
+				require.Equal(t, "ACL_NOT_OWNER", dbErr.Code)
+			},
+			expectedPrefix: "foo",
+		},
+		{
+			name:           "invalid prefix starting with a digit",
+			tableID:        "100",
+			caller:         owner,
+			newPrefix:      "1bar",
+			assertErr:      assertInvalidPrefix("1bar"),
+			expectedPrefix: "foo",
+		},
+		{
+			name:           "invalid prefix with a space",
+			tableID:        "100",
+			caller:         owner,
+			newPrefix:      "bar baz",
+			assertErr:      assertInvalidPrefix("bar baz"),
+			expectedPrefix: "foo",
+		},
+		{
+			name:           "invalid prefix with a statement",
+			tableID:        "100",
+			caller:         owner,
+			newPrefix:      "bar;drop table foo_1337_100",
+			assertErr:      assertInvalidPrefix("bar;drop table foo_1337_100"),
+			expectedPrefix: "foo",
+		},
+		{
+			name:      "not exist",
+			tableID:   "101",
+			caller:    owner,
+			newPrefix: "bar",
+			assertErr: func(t *testing.T, err error) {
+				var notExistErr *executor.ErrTableNotExist
+				require.ErrorAs(t, err, &notExistErr)
+			},
+			expectedPrefix: "foo",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := tables.NewTableID(tc.tableID)
+			require.NoError(t, err)
+			ex, dbURI := newExecutorWithIntegerTable(t, 0)
+			structure := tableReadString(t, dbURI, structureQuery)
+
+			bs, err := ex.NewBlockScope(ctx, 1)
+			require.NoError(t, err)
+			_, err = bs.(*blockScope).txn.ExecContext(ctx, "insert into foo_1337_100 values (42)")
+			require.NoError(t, err)
+			tc.assertErr(t, bs.RenameTable(ctx, id, tc.caller, tc.newPrefix))
+			require.NoError(t, bs.Commit())
+			require.NoError(t, bs.Close())
+			require.NoError(t, ex.Close(ctx))
+
+			tableName := fmt.Sprintf("%s_1337_100", tc.expectedPrefix)
+			require.True(t, existsTableWithName(t, dbURI, tableName))
+			if tc.expectedPrefix != "foo" {
+				require.False(t, existsTableWithName(t, dbURI, "foo_1337_100"))
+			}
+			require.Equal(t, 42, tableReadInteger(t, dbURI, fmt.Sprintf("select zar from %s", tableName)))
+			require.Equal(t, tc.expectedPrefix, tableReadString(t, dbURI, prefixQuery))
+			require.Equal(t, structure, tableReadString(t, dbURI, structureQuery))
+		})
+	}
+}
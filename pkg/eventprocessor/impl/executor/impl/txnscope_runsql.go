@@ -11,6 +11,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
@@ -23,19 +25,22 @@ func (ts *txnScope) executeRunSQLEvent(
 	mutatingStmts, err := ts.parser.ValidateMutatingQuery(e.Statement, ts.scopeVars.ChainID)
 	if err != nil {
 		err := fmt.Sprintf("parsing query: %s", err)
-		return eventExecutionResult{Error: &err}, nil
+		return eventExecutionResult{Error: &err, ErrorCode: eventprocessor.ErrorCodeInvalidSyntax}, nil
 	}
 	tableID := tables.TableID(*e.TableId)
 	targetedTableID := mutatingStmts[0].GetTableID()
 	if targetedTableID.ToBigInt().Cmp(tableID.ToBigInt()) != 0 {
 		err := fmt.Sprintf("query targets table id %s and not %s", targetedTableID, tableID)
-		return eventExecutionResult{Error: &err}, nil
+		return eventExecutionResult{Error: &err, ErrorCode: eventprocessor.ErrorCodeTableIDMismatch}, nil
 	}
 	if err := ts.execWriteQueries(ctx, e.Caller, mutatingStmts, e.IsOwner, &policy{e.Policy}); err != nil {
 		var dbErr *errQueryExecution
 		if errors.As(err, &dbErr) {
+			if isRejectionCode(dbErr.Code) {
+				ts.metrics.RecordRejection(dbErr.Code)
+			}
 			err := fmt.Sprintf("db query execution failed (code: %s, msg: %s)", dbErr.Code, dbErr.Msg)
-			return eventExecutionResult{Error: &err}, nil
+			return eventExecutionResult{Error: &err, ErrorCode: dbErr.ErrorCode}, nil
 		}
 		return eventExecutionResult{}, fmt.Errorf("executing mutating-query: %s", err)
 	}
@@ -50,17 +55,33 @@ func (ts *txnScope) execWriteQueries(
 	policy tableland.Policy,
 ) error {
 	if len(mqueries) == 0 {
+		if ts.scopeVars.RejectEmptyBatches {
+			return &errQueryExecution{
+				Code:      "EMPTY_BATCH",
+				Msg:       executor.ErrEmptyBatch.Error(),
+				ErrorCode: eventprocessor.ErrorCodeEmptyBatch,
+			}
+		}
 		ts.log.Warn().Msg("no mutating-queries to execute in a batch")
 		return nil
 	}
 
+	if maxStmts := ts.scopeVars.MaxBatchStatements; maxStmts > 0 && len(mqueries) > maxStmts {
+		return &errQueryExecution{
+			Code:      "TOO_MANY_STATEMENTS",
+			Msg:       (&executor.ErrTooManyStatements{Have: len(mqueries), Max: maxStmts}).Error(),
+			ErrorCode: eventprocessor.ErrorCodeTooManyStatements,
+		}
+	}
+
 	dbTableName := mqueries[0].GetDBTableName()
-	tablePrefix, beforeRowCount, err := getTablePrefixAndRowCountByTableID(
+	tablePrefix, rowCount, err := getTablePrefixAndRowCountByTableID(
 		ctx, ts.txn, ts.scopeVars.ChainID, mqueries[0].GetTableID(), dbTableName)
 	if err != nil {
 		return &errQueryExecution{
-			Code: "TABLE_LOOKUP",
-			Msg:  fmt.Sprintf("table prefix lookup for table id: %s", err),
+			Code:      "TABLE_LOOKUP",
+			Msg:       fmt.Sprintf("table prefix lookup for table id: %s", err),
+			ErrorCode: eventprocessor.ErrorCodeTableNotFound,
 		}
 	}
 
@@ -68,8 +89,9 @@ func (ts *txnScope) execWriteQueries(
 		mqPrefix := mq.GetPrefix()
 		if mqPrefix != "" && !strings.EqualFold(tablePrefix, mqPrefix) {
 			return &errQueryExecution{
-				Code: "TABLE_PREFIX",
-				Msg:  fmt.Sprintf("table prefix doesn't match (exp %s, got %s)", tablePrefix, mqPrefix),
+				Code:      "TABLE_PREFIX",
+				Msg:       fmt.Sprintf("table prefix doesn't match (exp %s, got %s)", tablePrefix, mqPrefix),
+				ErrorCode: eventprocessor.ErrorCodeTableIDMismatch,
 			}
 		}
 
@@ -80,7 +102,7 @@ func (ts *txnScope) execWriteQueries(
 				return fmt.Errorf("executing grant stmt: %w", err)
 			}
 		case parsing.WriteStmt:
-			if err := ts.executeWriteStmt(ctx, stmt, controller, policy, beforeRowCount); err != nil {
+			if err := ts.executeWriteStmt(ctx, stmt, controller, policy, &rowCount); err != nil {
 				return fmt.Errorf("executing write stmt: %w", err)
 			}
 		default:
@@ -97,26 +119,26 @@ func (ts *txnScope) executeGrantStmt(
 ) error {
 	if !isOwner {
 		return &errQueryExecution{
-			Code: "ACL_NOT_OWNER",
-			Msg:  "non owner cannot execute grant stmt",
+			Code:      "ACL_NOT_OWNER",
+			Msg:       "non owner cannot execute grant stmt",
+			ErrorCode: eventprocessor.ErrorCodeACLDenied,
 		}
 	}
 
-	for _, role := range gs.GetRoles() {
-		switch gs.Operation() {
-		case tableland.OpGrant:
-			if err := ts.executeGrantPrivilegesTx(ctx, gs.GetTableID(), role, gs.GetPrivileges()); err != nil {
-				return fmt.Errorf("executing grant privileges tx: %w", err)
-			}
-		case tableland.OpRevoke:
-			if err := ts.executeRevokePrivilegesTx(ctx, gs.GetTableID(), role, gs.GetPrivileges()); err != nil {
-				return fmt.Errorf("executing revoke privileges tx: %w", err)
-			}
-		default:
-			return &errQueryExecution{
-				Code: "ACL_UNKNOWN_OPERATION",
-				Msg:  fmt.Sprintf("unknown grant stmt operation=%s", gs.Operation().String()),
-			}
+	switch gs.Operation() {
+	case tableland.OpGrant:
+		if err := ts.executeGrantPrivilegesTx(ctx, gs.GetTableID(), gs.GetRoles(), gs.GetPrivileges()); err != nil {
+			return fmt.Errorf("executing grant privileges tx: %w", err)
+		}
+	case tableland.OpRevoke:
+		if err := ts.executeRevokePrivilegesTx(ctx, gs.GetTableID(), gs.GetRoles(), gs.GetPrivileges()); err != nil {
+			return fmt.Errorf("executing revoke privileges tx: %w", err)
+		}
+	default:
+		return &errQueryExecution{
+			Code:      "ACL_UNKNOWN_OPERATION",
+			Msg:       fmt.Sprintf("unknown grant stmt operation=%s", gs.Operation().String()),
+			ErrorCode: eventprocessor.ErrorCodeInvalidSyntax,
 		}
 	}
 
@@ -126,7 +148,7 @@ func (ts *txnScope) executeGrantStmt(
 func (ts *txnScope) executeGrantPrivilegesTx(
 	ctx context.Context,
 	id tables.TableID,
-	addr common.Address,
+	roles []common.Address,
 	privileges tableland.Privileges,
 ) error {
 	var privilegesMask int
@@ -143,22 +165,26 @@ func (ts *txnScope) executeGrantPrivilegesTx(
 		}
 	}
 
-	// Upserts the privileges into the acl table,
-	// making sure the array has unique elements.
-	if _, err := ts.txn.ExecContext(ctx,
+	// Upserts the privileges of all roles into the acl table in a single statement,
+	// merging them with the privileges each role already has.
+	values := make([]string, len(roles))
+	args := []interface{}{ts.scopeVars.ChainID, id.ToBigInt().Int64(), privilegesMask, time.Now().Unix()}
+	for i, role := range roles {
+		values[i] = fmt.Sprintf("(?1, ?2, ?%d, ?3, ?4)", len(args)+1)
+		args = append(args, role.Hex())
+	}
+	q := fmt.Sprintf(
 		`INSERT INTO system_acl ("chain_id","table_id","controller","privileges","created_at")
-		 VALUES (?1, ?2, ?3, ?4, ?5)
+		 VALUES %s
 		 ON CONFLICT (chain_id,table_id,controller)
-		 DO UPDATE SET privileges = privileges | ?4, updated_at = ?5`,
-		ts.scopeVars.ChainID,
-		id.ToBigInt().Int64(),
-		addr.Hex(),
-		privilegesMask,
-		time.Now().Unix()); err != nil {
+		 DO UPDATE SET privileges = privileges | ?3, updated_at = ?4`,
+		strings.Join(values, ","))
+	if _, err := ts.txn.ExecContext(ctx, q, args...); err != nil {
 		if code, ok := isErrCausedByQuery(err); ok {
 			return &errQueryExecution{
-				Code: "SQLITE_" + code,
-				Msg:  err.Error(),
+				Code:      "SQLITE_" + code,
+				Msg:       err.Error(),
+				ErrorCode: sqliteErrorCode(err),
 			}
 		}
 		return fmt.Errorf("creating/updating acl entry on system acl: %s", err)
@@ -170,7 +196,7 @@ func (ts *txnScope) executeGrantPrivilegesTx(
 func (ts *txnScope) executeRevokePrivilegesTx(
 	ctx context.Context,
 	id tables.TableID,
-	addr common.Address,
+	roles []common.Address,
 	privileges tableland.Privileges,
 ) error {
 	privilegesMask := tableland.PrivInsert.Bitfield | tableland.PrivUpdate.Bitfield | tableland.PrivDelete.Bitfield
@@ -191,20 +217,23 @@ func (ts *txnScope) executeRevokePrivilegesTx(
 		}
 	}
 
-	if _, err := ts.txn.ExecContext(ctx,
+	placeholders := make([]string, len(roles))
+	args := []interface{}{ts.scopeVars.ChainID, id.String(), privilegesMask, time.Now().Unix()}
+	for i, role := range roles {
+		placeholders[i] = fmt.Sprintf("?%d", len(args)+1)
+		args = append(args, role.Hex())
+	}
+	q := fmt.Sprintf(
 		`UPDATE system_acl 
-	     SET privileges = privileges & ?4, updated_at = ?5
-		 WHERE chain_id=?1 AND table_id = ?2 AND controller = ?3`,
-		ts.scopeVars.ChainID,
-		id.String(),
-		addr.Hex(),
-		privilegesMask,
-		time.Now().Unix(),
-	); err != nil {
+		 SET privileges = privileges & ?3, updated_at = ?4
+		 WHERE chain_id=?1 AND table_id = ?2 AND controller IN (%s)`,
+		strings.Join(placeholders, ","))
+	if _, err := ts.txn.ExecContext(ctx, q, args...); err != nil {
 		if code, ok := isErrCausedByQuery(err); ok {
 			return &errQueryExecution{
-				Code: "SQLITE_" + code,
-				Msg:  err.Error(),
+				Code:      "SQLITE_" + code,
+				Msg:       err.Error(),
+				ErrorCode: sqliteErrorCode(err),
 			}
 		}
 		return fmt.Errorf("removing acl entry from system acl: %s", err)
@@ -218,7 +247,7 @@ func (ts *txnScope) executeWriteStmt(
 	ws parsing.WriteStmt,
 	addr common.Address,
 	policy tableland.Policy,
-	beforeRowCount int,
+	rowCount *int,
 ) error {
 	controller, err := ts.getController(ctx, ws.GetTableID())
 	if err != nil {
@@ -236,26 +265,32 @@ func (ts *txnScope) executeWriteStmt(
 		}
 		if !ok {
 			return &errQueryExecution{
-				Code: "ACL",
-				Msg:  "not enough privileges",
+				Code:      "ACL",
+				Msg:       "not enough privileges",
+				ErrorCode: eventprocessor.ErrorCodeACLDenied,
 			}
 		}
 	}
 
 	if policy.WithCheck() == "" {
+		// Resolving the query replaces custom functions in the statement, so the canonical
+		// query must be taken first.
+		canonicalQuery := ws.GetCanonicalQuery()
 		query, err := ws.GetQuery(ts.statementResolver)
 		if err != nil {
 			return &errQueryExecution{
-				Code: "QUERY_RESOLUTION",
-				Msg:  err.Error(),
+				Code:      "QUERY_RESOLUTION",
+				Msg:       err.Error(),
+				ErrorCode: eventprocessor.ErrorCodeExecutionFailed,
 			}
 		}
-		cmdTag, err := ts.txn.ExecContext(ctx, query)
+		cmdTag, err := ts.stmts.ExecContext(ctx, canonicalQuery, query)
 		if err != nil {
 			if code, ok := isErrCausedByQuery(err); ok {
 				return &errQueryExecution{
-					Code: "SQLITE_" + code,
-					Msg:  err.Error(),
+					Code:      "SQLITE_" + code,
+					Msg:       err.Error(),
+					ErrorCode: sqliteErrorCode(err),
 				}
 			}
 			return fmt.Errorf("exec query: %s", err)
@@ -267,18 +302,30 @@ func (ts *txnScope) executeWriteStmt(
 		}
 
 		isInsert := ws.Operation() == tableland.OpInsert
-		if err := ts.checkRowCountLimit(ra, isInsert, beforeRowCount); err != nil {
+		if err := ts.checkRowCountLimit(ra, isInsert, *rowCount); err != nil {
 			return fmt.Errorf("check row limit: %w", err)
 		}
+		trackRowCount(rowCount, ws.Operation(), ra)
+		ts.metrics.RecordStmtExecuted(ws.Operation(), ra)
 
-		return nil
+		return ts.insertAuditEntry(ctx, ws.GetTableID(), ws.Operation(), addr, ra)
+	}
+
+	withCheck, err := ws.BuildPolicyClause(policy.WithCheck())
+	if err != nil {
+		return &errQueryExecution{
+			Code:      "POLICY_WITH_CHECK",
+			Msg:       err.Error(),
+			ErrorCode: eventprocessor.ErrorCodePolicyDenied,
+		}
 	}
 
 	if err := ws.AddReturningClause(); err != nil {
 		if err != parsing.ErrCantAddReturningOnDELETE {
 			return &errQueryExecution{
-				Code: "POLICY_APPLY_RETURNING_CLAUSE",
-				Msg:  err.Error(),
+				Code:      "POLICY_APPLY_RETURNING_CLAUSE",
+				Msg:       err.Error(),
+				ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 			}
 		}
 		ts.log.Warn().Err(err).Msg("add returning clause called on delete")
@@ -287,8 +334,9 @@ func (ts *txnScope) executeWriteStmt(
 	query, err := ws.GetQuery(ts.statementResolver)
 	if err != nil {
 		return &errQueryExecution{
-			Code: "QUERY_RESOLUTION",
-			Msg:  err.Error(),
+			Code:      "QUERY_RESOLUTION",
+			Msg:       err.Error(),
+			ErrorCode: eventprocessor.ErrorCodeExecutionFailed,
 		}
 	}
 
@@ -298,18 +346,52 @@ func (ts *txnScope) executeWriteStmt(
 	}
 
 	isInsert := ws.Operation() == tableland.OpInsert
-	if err := ts.checkRowCountLimit(int64(len(affectedRowIDs)), isInsert, beforeRowCount); err != nil {
+	if err := ts.checkRowCountLimit(int64(len(affectedRowIDs)), isInsert, *rowCount); err != nil {
 		return fmt.Errorf("check row limit: %w", err)
 	}
+	trackRowCount(rowCount, ws.Operation(), int64(len(affectedRowIDs)))
 
 	// If the executed query returned rowids for the affected rows,
 	// we need to execute an auditing SQL built from the policy
 	// and match the result of this SQL to the number of affected rows
-	sql := buildAuditingQueryFromPolicy(ws.GetDBTableName(), affectedRowIDs, policy)
+	sql := buildAuditingQueryFromPolicy(ws.GetDBTableName(), affectedRowIDs, withCheck)
 	if err := ts.checkAffectedRowsAgainstAuditingQuery(ctx, len(affectedRowIDs), sql); err != nil {
 		return fmt.Errorf("check affected rows against auditing query: %w", err)
 	}
+	ts.metrics.RecordStmtExecuted(ws.Operation(), int64(len(affectedRowIDs)))
+
+	return ts.insertAuditEntry(ctx, ws.GetTableID(), ws.Operation(), addr, int64(len(affectedRowIDs)))
+}
 
+// insertAuditEntry records an executed write operation in the audit log. Since it's inserted
+// in the same transaction, it's only persisted if the operation changes are committed.
+// The entry creation time is the block time if it's known, and the current time otherwise.
+func (ts *txnScope) insertAuditEntry(
+	ctx context.Context,
+	tableID tables.TableID,
+	op tableland.Operation,
+	controller common.Address,
+	rowsAffected int64,
+) error {
+	createdAt := time.Now()
+	if !ts.scopeVars.BlockTime.IsZero() {
+		createdAt = ts.scopeVars.BlockTime
+	}
+	if _, err := ts.txn.ExecContext(ctx,
+		`INSERT INTO system_audit
+		 ("chain_id","table_id","controller","operation","rows_affected","block_number","txn_hash","created_at")
+		 VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)`,
+		ts.scopeVars.ChainID,
+		tableID.ToBigInt().Int64(),
+		controller.Hex(),
+		op.String(),
+		rowsAffected,
+		ts.scopeVars.BlockNumber,
+		ts.txnHash.Hex(),
+		createdAt.Unix(),
+	); err != nil {
+		return fmt.Errorf("inserting audit entry: %s", err)
+	}
 	return nil
 }
 
@@ -322,16 +404,18 @@ func (ts *txnScope) checkAffectedRowsAgainstAuditingQuery(
 	if err := ts.txn.QueryRowContext(ctx, sql).Scan(&count); err != nil {
 		if code, ok := isErrCausedByQuery(err); ok {
 			return &errQueryExecution{
-				Code: "SQLITE_" + code,
-				Msg:  err.Error(),
+				Code:      "SQLITE_" + code,
+				Msg:       err.Error(),
+				ErrorCode: sqliteErrorCode(err),
 			}
 		}
 		return fmt.Errorf("checking affected rows query exec: %s", err)
 	}
 	if count != affectedRowsCount {
 		return &errQueryExecution{
-			Code: "POLICY_WITH_CHECK",
-			Msg:  fmt.Sprintf("number of affected rows %d does not match auditing count %d", affectedRowsCount, count),
+			Code:      "POLICY_WITH_CHECK",
+			Msg:       fmt.Sprintf("number of affected rows %d does not match auditing count %d", affectedRowsCount, count),
+			ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 		}
 	}
 
@@ -355,8 +439,9 @@ func (ts *txnScope) executeQueryAndGetAffectedRows(
 	if err != nil {
 		if code, ok := isErrCausedByQuery(err); ok {
 			return nil, &errQueryExecution{
-				Code: "SQLITE_" + code,
-				Msg:  err.Error(),
+				Code:      "SQLITE_" + code,
+				Msg:       err.Error(),
+				ErrorCode: sqliteErrorCode(err),
 			}
 		}
 		return nil, fmt.Errorf("exec query: %s", err)
@@ -379,8 +464,9 @@ func (ts *txnScope) checkRowCountLimit(rowsAffected int64, isInsert bool, before
 
 		if afterRowCount > ts.scopeVars.MaxTableRowCount {
 			return &errQueryExecution{
-				Code: "ROW_COUNT_LIMIT",
-				Msg:  fmt.Sprintf("table maximum row count exceeded (before %d, after %d)", beforeRowCount, afterRowCount),
+				Code:      "ROW_COUNT_LIMIT",
+				Msg:       fmt.Sprintf("table maximum row count exceeded (before %d, after %d)", beforeRowCount, afterRowCount),
+				ErrorCode: eventprocessor.ErrorCodeRowCountExceeded,
 			}
 		}
 	}
@@ -388,25 +474,40 @@ func (ts *txnScope) checkRowCountLimit(rowsAffected int64, isInsert bool, before
 	return nil
 }
 
+// trackRowCount keeps the table row count up to date after executing a statement, so the
+// row limit check of the following statements in the same batch is accurate. An unconditional
+// DELETE affects every row, so it leaves the row count at zero.
+func trackRowCount(rowCount *int, op tableland.Operation, rowsAffected int64) {
+	switch op {
+	case tableland.OpInsert:
+		*rowCount += int(rowsAffected)
+	case tableland.OpDelete:
+		*rowCount -= int(rowsAffected)
+	}
+}
+
 func (ts *txnScope) applyPolicy(ws parsing.WriteStmt, policy tableland.Policy) error {
 	if ws.Operation() == tableland.OpInsert && !policy.IsInsertAllowed() {
 		return &errQueryExecution{
-			Code: "POLICY",
-			Msg:  "insert is not allowed by policy",
+			Code:      "POLICY",
+			Msg:       "insert is not allowed by policy",
+			ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 		}
 	}
 
 	if ws.Operation() == tableland.OpUpdate && !policy.IsUpdateAllowed() {
 		return &errQueryExecution{
-			Code: "POLICY",
-			Msg:  "update is not allowed by policy",
+			Code:      "POLICY",
+			Msg:       "update is not allowed by policy",
+			ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 		}
 	}
 
 	if ws.Operation() == tableland.OpDelete && !policy.IsDeleteAllowed() {
 		return &errQueryExecution{
-			Code: "POLICY",
-			Msg:  "delete is not allowed by policy",
+			Code:      "POLICY",
+			Msg:       "delete is not allowed by policy",
+			ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 		}
 	}
 
@@ -417,8 +518,9 @@ func (ts *txnScope) applyPolicy(ws parsing.WriteStmt, policy tableland.Policy) e
 			if err := ws.CheckColumns(columnsAllowed); err != nil {
 				if err != parsing.ErrCanOnlyCheckColumnsOnUPDATE {
 					return &errQueryExecution{
-						Code: "POLICY_CHECK_COLUMNS",
-						Msg:  err.Error(),
+						Code:      "POLICY_CHECK_COLUMNS",
+						Msg:       err.Error(),
+						ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 					}
 				}
 				ts.log.Warn().Err(err).Msg("check columns being called on insert or delete")
@@ -432,8 +534,9 @@ func (ts *txnScope) applyPolicy(ws parsing.WriteStmt, policy tableland.Policy) e
 			if err := ws.AddWhereClause(policy.WhereClause()); err != nil {
 				if err != parsing.ErrCantAddWhereOnINSERT {
 					return &errQueryExecution{
-						Code: "POLICY_APPLY_WHERE_CLAUSE",
-						Msg:  err.Error(),
+						Code:      "POLICY_APPLY_WHERE_CLAUSE",
+						Msg:       err.Error(),
+						ErrorCode: eventprocessor.ErrorCodePolicyDenied,
 					}
 				}
 				ts.log.Warn().Err(err).Msg("add where clause called on insert")
@@ -463,7 +566,7 @@ func (ts *txnScope) getController(
 	return controller, nil
 }
 
-func buildAuditingQueryFromPolicy(dbTableName string, rowIDs []int64, policy tableland.Policy) string {
+func buildAuditingQueryFromPolicy(dbTableName string, rowIDs []int64, withCheck string) string {
 	ids := make([]string, len(rowIDs))
 	for i, id := range rowIDs {
 		ids[i] = strconv.FormatInt(id, 10)
@@ -471,13 +574,33 @@ func buildAuditingQueryFromPolicy(dbTableName string, rowIDs []int64, policy tab
 	return fmt.Sprintf(
 		"SELECT count(1) FROM %s WHERE (%s) AND rowid in (%s) LIMIT 1",
 		dbTableName,
-		policy.WithCheck(),
+		withCheck,
 		strings.Join(ids, ","),
 	)
 }
 
 // getTablePrefixAndRowCountByTableID returns the table prefix and current row count for a TableID
 // within the provided transaction.
+// getTablePrefixByTableID returns the prefix of a table. If the table isn't in the registry,
+// it returns an *executor.ErrTableNotExist error.
+func getTablePrefixByTableID(
+	ctx context.Context,
+	tx *sql.Tx,
+	chainID tableland.ChainID,
+	tableID tables.TableID,
+) (string, error) {
+	var prefix string
+	err := tx.QueryRowContext(ctx,
+		"SELECT prefix FROM registry WHERE chain_id=?1 AND id=?2", chainID, tableID.String()).Scan(&prefix)
+	if err == sql.ErrNoRows {
+		return "", &executor.ErrTableNotExist{TableID: tableID}
+	}
+	if err != nil {
+		return "", fmt.Errorf("table prefix lookup: %s", err)
+	}
+	return prefix, nil
+}
+
 func getTablePrefixAndRowCountByTableID(
 	ctx context.Context,
 	tx *sql.Tx,
@@ -491,16 +614,41 @@ func getTablePrefixAndRowCountByTableID(
 
 	var tablePrefix string
 	var rowCount int
-	err := r.Scan(&tablePrefix, &rowCount)
-	if err == sql.ErrNoRows {
-		return "", 0, fmt.Errorf("the table id doesn't exist")
-	}
-	if err != nil {
-		return "", 0, fmt.Errorf("table prefix lookup: %s", err)
+	if err := r.Scan(&tablePrefix, &rowCount); err != nil {
+		// The message of a failed lookup is recorded in receipts, so it's kept as the database
+		// reported it. A missing registry entry is still typed for callers to match.
+		lookupErr := fmt.Errorf("table prefix lookup: %s", err)
+		var exists bool
+		if err := tx.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM registry WHERE chain_id=?1 AND id=?2)", chainID, tableID.String(),
+		).Scan(&exists); err == nil && !exists {
+			return "", 0, &errTableLookup{msg: lookupErr.Error(), notExist: &executor.ErrTableNotExist{TableID: tableID}}
+		}
+		return "", 0, lookupErr
 	}
 	return tablePrefix, rowCount, nil
 }
 
+// errTableLookup is a failed table lookup for a table that isn't in the registry.
+type errTableLookup struct {
+	msg      string
+	notExist *executor.ErrTableNotExist
+}
+
+func (e *errTableLookup) Error() string {
+	return e.msg
+}
+
+func (e *errTableLookup) Unwrap() error {
+	return e.notExist
+}
+
+// isRejectionCode returns true if the query execution error code indicates that
+// the statement was rejected by the ACL or the controller policy.
+func isRejectionCode(code string) bool {
+	return strings.HasPrefix(code, "ACL") || strings.HasPrefix(code, "POLICY")
+}
+
 type policy struct {
 	ethereum.ITablelandControllerPolicy
 }
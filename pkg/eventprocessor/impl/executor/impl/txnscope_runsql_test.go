@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
@@ -189,6 +192,46 @@ func TestRunSQL_OneEventPerTxn(t *testing.T) {
 		}
 	})
 
+	t.Run("grant multiple roles", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+
+		// 0xd43c59d5694ec111eb9e986c233200b14249558d already has the update privilege
+		// before being granted along with the other roles.
+		q := "grant update on foo_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d';"
+		q += "grant insert, delete on foo_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d', '0x4afe8e30db4549384b0a05bb796468b130c7d6e0', '0x2a3a4b4c4d4e4f5061626364656667686970717a';" //nolint
+		q += "revoke delete on foo_1337_100 from '0x4afe8e30db4549384b0a05bb796468b130c7d6e0', '0x2a3a4b4c4d4e4f5061626364656667686970717a'"                                                     //nolint
+		assertExecTxnWithRunSQLEvents(t, bs, []string{q})
+
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		systemStore, err := system.New(dbURI, tableland.ChainID(chainID))
+		require.NoError(t, err)
+
+		tableID, _ := tables.NewTableID("100")
+		expPrivileges := map[string]tableland.Privileges{
+			"0xD43C59d5694eC111Eb9e986C233200b14249558D": {
+				tableland.PrivInsert, tableland.PrivUpdate, tableland.PrivDelete,
+			},
+			"0x4afE8e30DB4549384b0a05bb796468B130c7D6E0":                            {tableland.PrivInsert},
+			common.HexToAddress("0x2a3a4b4c4d4e4f5061626364656667686970717a").Hex(): {tableland.PrivInsert},
+		}
+		for controller, privileges := range expPrivileges {
+			aclRow, err := systemStore.GetACLOnTableByController(ctx, tableID, controller)
+			require.NoError(t, err)
+			require.Equal(t, tableID, aclRow.TableID)
+			require.Equal(t, controller, aclRow.Controller)
+			require.ElementsMatch(t, privileges, aclRow.Privileges)
+		}
+	})
+
 	t.Run("grant revoke", func(t *testing.T) {
 		t.Parallel()
 		ctx := context.Background()
@@ -352,7 +395,7 @@ func TestRunSQL_RowCountLimit(t *testing.T) {
 	ex, dbURI := newExecutorWithStringTable(t, rowLimit)
 
 	// Helper func to insert a row and return the result.
-	insertRow := func(t *testing.T) *string {
+	insertRow := func(t *testing.T) executor.TxnExecutionResult {
 		bs, err := ex.NewBlockScope(ctx, 0)
 		require.NoError(t, err)
 
@@ -362,26 +405,310 @@ func TestRunSQL_RowCountLimit(t *testing.T) {
 			require.NoError(t, bs.Commit())
 		}
 		require.NoError(t, bs.Close())
-		return res.Error
+		return res
 	}
 
 	// Insert up to 10 rows should succeed.
 	for i := 0; i < rowLimit; i++ {
-		require.Nil(t, insertRow(t))
+		require.Nil(t, insertRow(t).Error)
 	}
 	require.Equal(t, rowLimit, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
 
 	// The next insert should fail.
-	err := insertRow(t)
+	res := insertRow(t)
+	require.Contains(t, *res.Error,
+		fmt.Sprintf("table maximum row count exceeded (before %d, after %d)", rowLimit, rowLimit+1),
+	)
+	require.Equal(t, eventprocessor.ErrorCodeRowCountExceeded, res.ErrorCode)
+
+	require.NoError(t, ex.Close(ctx))
+}
+
+func TestRunSQL_ReceiptErrorCodes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, _ := newExecutorWithTable(t, 0, "create table foo_1337 (zar text unique)")
+
+	tests := []struct {
+		name      string
+		statement string
+		errorCode string
+	}{
+		{
+			name:      "invalid syntax",
+			statement: "insert into foo_1337_100 valuez ('one')",
+			errorCode: eventprocessor.ErrorCodeInvalidSyntax,
+		},
+		{
+			name:      "table id mismatch",
+			statement: "insert into foo_1337_101 values ('one')",
+			errorCode: eventprocessor.ErrorCodeTableIDMismatch,
+		},
+		{
+			name:      "table not found",
+			statement: "insert into bar_1337_100 values ('one')",
+			errorCode: eventprocessor.ErrorCodeTableNotFound,
+		},
+		{
+			name:      "constraint violation",
+			statement: "insert into foo_1337_100 values ('one'); insert into foo_1337_100 values ('one')",
+			errorCode: eventprocessor.ErrorCodeConstraintViolation,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bs, err := ex.NewBlockScope(ctx, 0)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, bs.Close()) }()
+
+			_, res, err := execTxnWithRunSQLEvents(t, bs, []string{tc.statement})
+			require.NoError(t, err)
+			require.NotNil(t, res.Error)
+			require.Equal(t, tc.errorCode, res.ErrorCode)
+		})
+	}
+}
+
+func TestRunSQL_RowCountLimitPerExecutor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Each chain has its own executor, so a per-chain row count limit must be enforced
+	// by each executor independently of the others.
+	lowLimit, highLimit := 2, 5
+	lowEx, lowDBURI := newExecutorWithStringTable(t, lowLimit)
+	highEx, highDBURI := newExecutorWithStringTable(t, highLimit)
+
+	insertRows := func(t *testing.T, ex *Executor, n int) executor.TxnExecutionResult {
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+
+		stmts := make([]string, n)
+		for i := range stmts {
+			stmts[i] = "insert into foo_1337_100 values ('one')"
+		}
+		_, res, err := execTxnWithRunSQLEvents(t, bs, []string{strings.Join(stmts, ";")})
+		require.NoError(t, err)
+		if res.Error == nil {
+			require.NoError(t, bs.Commit())
+		}
+		require.NoError(t, bs.Close())
+		return res
+	}
+
+	// Going over the lower limit fails only in the executor configured with it.
+	res := insertRows(t, lowEx, lowLimit+1)
+	require.Equal(t, eventprocessor.ErrorCodeRowCountExceeded, res.ErrorCode)
+	require.Contains(t, *res.Error,
+		fmt.Sprintf("table maximum row count exceeded (before %d, after %d)", lowLimit, lowLimit+1),
+	)
+	require.Nil(t, insertRows(t, highEx, lowLimit+1).Error)
+
+	require.Nil(t, insertRows(t, lowEx, lowLimit).Error)
+	require.Equal(t, lowLimit, tableReadInteger(t, lowDBURI, "select count(*) from foo_1337_100"))
+
+	// The executor with the higher limit accepts rows up to its own limit, and no more.
+	require.Nil(t, insertRows(t, highEx, highLimit-lowLimit-1).Error)
+	require.Equal(t, highLimit, tableReadInteger(t, highDBURI, "select count(*) from foo_1337_100"))
+	res = insertRows(t, highEx, 1)
+	require.Equal(t, eventprocessor.ErrorCodeRowCountExceeded, res.ErrorCode)
+	require.Contains(t, *res.Error,
+		fmt.Sprintf("table maximum row count exceeded (before %d, after %d)", highLimit, highLimit+1),
+	)
+
+	require.NoError(t, lowEx.Close(ctx))
+	require.NoError(t, highEx.Close(ctx))
+}
+
+func TestRunSQL_RowCountLimitWithDelete(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	rowLimit := 10
+	ex, dbURI := newExecutorWithStringTable(t, rowLimit)
+
+	// Helper func to execute a batch of statements in a single event and return the result.
+	execBatch := func(t *testing.T, stmts ...string) *string {
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+
+		_, res, err := execTxnWithRunSQLEvents(t, bs, []string{strings.Join(stmts, ";")})
+		require.NoError(t, err)
+		if res.Error == nil {
+			require.NoError(t, bs.Commit())
+		}
+		require.NoError(t, bs.Close())
+		return res.Error
+	}
+	inserts := func(n int) []string {
+		stmts := make([]string, n)
+		for i := range stmts {
+			stmts[i] = "insert into foo_1337_100 values ('one')"
+		}
+		return stmts
+	}
+
+	// Inserts in the same batch are accounted together.
+	err := execBatch(t, inserts(rowLimit+1)...)
 	require.Contains(t, *err,
 		fmt.Sprintf("table maximum row count exceeded (before %d, after %d)", rowLimit, rowLimit+1),
 	)
+	require.Equal(t, 0, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+
+	require.Nil(t, execBatch(t, inserts(rowLimit)...))
+	require.Equal(t, rowLimit, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+
+	// An unconditional delete frees the whole table, so inserting up to the cap
+	// in the same batch succeeds.
+	require.Nil(t, execBatch(t, append([]string{"delete from foo_1337_100"}, inserts(rowLimit)...)...))
+	require.Equal(t, rowLimit, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+
+	// And also in a later batch.
+	require.Nil(t, execBatch(t, "delete from foo_1337_100"))
+	require.Nil(t, execBatch(t, inserts(rowLimit)...))
+	require.Equal(t, rowLimit, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
 
 	require.NoError(t, ex.Close(ctx))
 }
 
+func TestRunSQL_MaxBatchStatements(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, dbURI := newExecutorWithIntegerTable(t, 0, WithMaxBatchStatements(3))
+
+	execBatch := func(t *testing.T, n int) *string {
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+
+		stmts := make([]string, n)
+		for i := range stmts {
+			stmts[i] = "insert into foo_1337_100 values (1)"
+		}
+		_, res, err := execTxnWithRunSQLEvents(t, bs, []string{strings.Join(stmts, ";")})
+		require.NoError(t, err)
+		if res.Error == nil {
+			require.NoError(t, bs.Commit())
+		}
+		require.NoError(t, bs.Close())
+		return res.Error
+	}
+
+	require.Nil(t, execBatch(t, 3))
+	require.Equal(t, 3, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+
+	err := execBatch(t, 4)
+	require.NotNil(t, err)
+	require.Contains(t, *err, "the query has 4 statements but the maximum allowed is 3")
+	require.Equal(t, 3, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+
+	require.NoError(t, ex.Close(ctx))
+}
+
+func TestRunSQL_EmptyBatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	execEmptyBatch := func(t *testing.T, opts ...Option) error {
+		ex, _ := newExecutorWithIntegerTable(t, 0, opts...)
+		ibs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+		bs := ibs.(*blockScope)
+		defer func() { require.NoError(t, bs.Close()) }()
+
+		ts := &txnScope{
+			scopeVars: bs.scopeVars,
+			parser:    bs.parser,
+			acl:       bs.acl,
+			metrics:   bs.metrics,
+			txn:       bs.txn,
+			stmts:     bs.stmts,
+		}
+		return ts.execWriteQueries(ctx, common.Address{}, nil, true, nil)
+	}
+
+	t.Run("allowed by default", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, execEmptyBatch(t))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+
+		err := execEmptyBatch(t, WithRejectEmptyBatches(true))
+		var dbErr *errQueryExecution
+		require.ErrorAs(t, err, &dbErr)
+		require.Equal(t, "EMPTY_BATCH", dbErr.Code)
+		require.Equal(t, executor.ErrEmptyBatch.Error(), dbErr.Msg)
+	})
+}
+
+func TestRunSQL_TableNotExist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, _ := newExecutorWithIntegerTable(t, 0)
+
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0xF2"),
+		Events: []interface{}{
+			&ethereum.ContractRunSQL{
+				IsOwner:   true,
+				TableId:   big.NewInt(101),
+				Statement: "insert into foo_1337_101 values (1)",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	require.Contains(t, *res.Error, "no such table: foo_1337_101")
+
+	// The lookup error is typed, so it can be told apart from infrastructure failures.
+	tableID, err := tables.NewTableID("101")
+	require.NoError(t, err)
+	_, _, err = getTablePrefixAndRowCountByTableID(ctx, bs.(*blockScope).txn, 1337, tableID, "foo_1337_101")
+	var notExistErr *executor.ErrTableNotExist
+	require.ErrorAs(t, err, &notExistErr)
+	require.Equal(t, tableID, notExistErr.TableID)
+
+	require.NoError(t, bs.Close())
+	require.NoError(t, ex.Close(ctx))
+}
+
 func TestWithCheck(t *testing.T) {
 	t.Parallel()
+	t.Run("with check injection is rejected", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		ex, dbURI := newExecutorWithStringTable(t, 0)
+
+		bs, err := ex.NewBlockScope(ctx, 0)
+		require.NoError(t, err)
+
+		// set the controller to anything other than zero
+		assertExecTxnWithSetController(t, bs, "0x1")
+
+		policy := ethereum.ITablelandControllerPolicy{
+			AllowInsert: true,
+			WithCheck:   "1 = 1) or (1 = 1",
+		}
+		_, res, err := execTxnWithRunSQLEventsAndPolicy(t, bs, []string{`insert into foo_1337_100 values ('one')`}, policy)
+		require.NoError(t, err)
+		require.NotNil(t, res.Error)
+		require.Contains(t, *res.Error, "policy clause '1 = 1) or (1 = 1' is invalid")
+
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+		require.NoError(t, ex.Close(ctx))
+
+		require.Equal(t, 0, tableReadInteger(t, dbURI, "select count(*) from foo_1337_100"))
+	})
+
 	t.Run("insert with check not satistifed", func(t *testing.T) {
 		t.Parallel()
 		ctx := context.Background()
@@ -511,6 +838,83 @@ func TestWithCheck(t *testing.T) {
 	})
 }
 
+func TestRunSQL_AuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+
+	insertTxnHash, res, err := execTxnWithRunSQLEvents(t, bs, []string{`insert into foo_1337_100 values (1), (2)`})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	deleteTxnHash, res, err := execTxnWithRunSQLEvents(t, bs, []string{`delete from foo_1337_100 where zar = 1`})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	// The insert is rolled back since the second statement references a wrong table.
+	_, res, err = execTxnWithRunSQLEvents(
+		t, bs, []string{`insert into foo_1337_100 values (3);insert into foo_1337_101 values (4)`})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+	require.NoError(t, ex.Close(ctx))
+
+	systemStore, err := system.New(dbURI, tableland.ChainID(chainID))
+	require.NoError(t, err)
+	tableID, err := tables.NewTableID("100")
+	require.NoError(t, err)
+
+	entries, err := systemStore.GetAuditLog(ctx, tableID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, tableland.OpInsert.String(), entries[0].Operation)
+	require.Equal(t, int64(2), entries[0].RowsAffected)
+	require.Equal(t, insertTxnHash.Hex(), entries[0].TxnHash)
+	require.Equal(t, common.Address{}.Hex(), entries[0].Controller)
+
+	require.Equal(t, tableland.OpDelete.String(), entries[1].Operation)
+	require.Equal(t, int64(1), entries[1].RowsAffected)
+	require.Equal(t, deleteTxnHash.Hex(), entries[1].TxnHash)
+
+	// Paging after the first entry returns the second one.
+	entries, err = systemStore.GetAuditLog(ctx, tableID, entries[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, deleteTxnHash.Hex(), entries[0].TxnHash)
+}
+
+func TestRunSQL_AuditLogUsesBlockTime(t *testing.T) {
+	t.Parallel()
+
+	blockTime := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	ctx := executor.ContextWithBlockTime(context.Background(), blockTime)
+	ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+	_, res, err := execTxnWithRunSQLEvents(t, bs, []string{`insert into foo_1337_100 values (1)`})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+	require.NoError(t, ex.Close(ctx))
+
+	systemStore, err := system.New(dbURI, tableland.ChainID(chainID))
+	require.NoError(t, err)
+	tableID, err := tables.NewTableID("100")
+	require.NoError(t, err)
+
+	entries, err := systemStore.GetAuditLog(ctx, tableID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, blockTime.Unix(), entries[0].CreatedAt.Unix())
+}
+
 func assertExecTxnWithRunSQLEvents(t *testing.T, bs executor.BlockScope, stmts []string) {
 	t.Helper()
 
@@ -521,7 +925,7 @@ func assertExecTxnWithRunSQLEvents(t *testing.T, bs executor.BlockScope, stmts [
 }
 
 func execTxnWithRunSQLEvents(
-	t *testing.T,
+	t testing.TB,
 	bs executor.BlockScope,
 	stmts []string,
 ) (common.Hash, executor.TxnExecutionResult, error) {
@@ -540,7 +944,7 @@ func execTxnWithRunSQLEvents(
 }
 
 func execTxnWithRunSQLEventsAndPolicy(
-	t *testing.T,
+	t testing.TB,
 	bs executor.BlockScope,
 	stmts []string,
 	policy ethereum.ITablelandControllerPolicy,
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
 )
@@ -15,7 +16,7 @@ func (ts *txnScope) executeSetControllerEvent(
 	e *ethereum.ContractSetController,
 ) (eventExecutionResult, error) {
 	if e.TableId == nil {
-		return eventExecutionResult{Error: &tableIDIsEmpty}, nil
+		return eventExecutionResult{Error: &tableIDIsEmpty, ErrorCode: eventprocessor.ErrorCodeMissingTableID}, nil
 	}
 	tableID := tables.TableID(*e.TableId)
 
@@ -23,7 +24,7 @@ func (ts *txnScope) executeSetControllerEvent(
 		var dbErr *errQueryExecution
 		if errors.As(err, &dbErr) {
 			err := fmt.Sprintf("set controller execution failed (code: %s, msg: %s)", dbErr.Code, dbErr.Msg)
-			return eventExecutionResult{Error: &err}, nil
+			return eventExecutionResult{Error: &err, ErrorCode: dbErr.ErrorCode}, nil
 		}
 		return eventExecutionResult{}, fmt.Errorf("executing set controller: %s", err)
 	}
@@ -45,8 +46,9 @@ func (ts *txnScope) setController(
 		); err != nil {
 			if code, ok := isErrCausedByQuery(err); ok {
 				return &errQueryExecution{
-					Code: "SQLITE_" + code,
-					Msg:  err.Error(),
+					Code:      "SQLITE_" + code,
+					Msg:       err.Error(),
+					ErrorCode: sqliteErrorCode(err),
 				}
 			}
 			return fmt.Errorf("deleting entry from system controller: %s", err)
@@ -63,8 +65,9 @@ func (ts *txnScope) setController(
 		); err != nil {
 			if code, ok := isErrCausedByQuery(err); ok {
 				return &errQueryExecution{
-					Code: "SQLITE_" + code,
-					Msg:  err.Error(),
+					Code:      "SQLITE_" + code,
+					Msg:       err.Error(),
+					ErrorCode: sqliteErrorCode(err),
 				}
 			}
 			return fmt.Errorf("inserting new entry into system controller: %s", err)
@@ -0,0 +1,40 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+// setTableDescription sets the description of a table, after checking that the table exists
+// and is owned by owner. Descriptions live outside the registry, so they don't change the state hash.
+func (ts *txnScope) setTableDescription(
+	ctx context.Context,
+	id tables.TableID,
+	owner common.Address,
+	description string,
+) error {
+	length := utf8.RuneCountInString(description)
+	if ts.scopeVars.MaxDescriptionLen > 0 && length > ts.scopeVars.MaxDescriptionLen {
+		return &executor.ErrDescriptionTooLong{Length: length, Max: ts.scopeVars.MaxDescriptionLen}
+	}
+
+	if _, err := ts.checkTableOwner(ctx, id, owner); err != nil {
+		return err
+	}
+
+	if _, err := ts.txn.ExecContext(ctx,
+		`INSERT INTO system_table_descriptions (chain_id, table_id, description) VALUES (?1, ?2, ?3)
+		ON CONFLICT (chain_id, table_id) DO UPDATE SET description = ?3`,
+		ts.scopeVars.ChainID,
+		id.String(),
+		description); err != nil {
+		return fmt.Errorf("updating table description: %s", err)
+	}
+
+	return nil
+}
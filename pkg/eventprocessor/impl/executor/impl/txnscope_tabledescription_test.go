@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestSetTableDescription(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	descriptionQuery := fmt.Sprintf(
+		"select coalesce((select description from system_table_descriptions where table_id = 100 and chain_id = %d), '')",
+		chainID)
+
+	tests := []struct {
+		name        string
+		tableID     string
+		caller      common.Address
+		description string
+
+		assertErr           func(t *testing.T, err error)
+		expectedDescription string
+	}{
+		{
+			name:        "owner",
+			tableID:     "100",
+			caller:      owner,
+			description: "my table",
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			expectedDescription: "my table",
+		},
+		{
+			name:        "non-owner",
+			tableID:     "100",
+			caller:      common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2"),
+			description: "mine",
+			assertErr: func(t *testing.T, err error) {
+				var dbErr *errQueryExecution
+				require.ErrorAs(t, err, &dbErr)
+				require.Equal(t, "ACL_NOT_OWNER", dbErr.Code)
+			},
+			expectedDescription: "",
+		},
+		{
+			name:        "max length",
+			tableID:     "100",
+			caller:      owner,
+			description: strings.Repeat("a", 10),
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			expectedDescription: strings.Repeat("a", 10),
+		},
+		{
+			name:        "too long",
+			tableID:     "100",
+			caller:      owner,
+			description: strings.Repeat("b", 11),
+			assertErr: func(t *testing.T, err error) {
+				var tooLongErr *executor.ErrDescriptionTooLong
+				require.ErrorAs(t, err, &tooLongErr)
+				require.Equal(t, 11, tooLongErr.Length)
+				require.Equal(t, 10, tooLongErr.Max)
+			},
+			expectedDescription: "",
+		},
+		{
+			name:        "not exist",
+			tableID:     "101",
+			caller:      owner,
+			description: "my table",
+			assertErr: func(t *testing.T, err error) {
+				var notExistErr *executor.ErrTableNotExist
+				require.ErrorAs(t, err, &notExistErr)
+			},
+			expectedDescription: "",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := tables.NewTableID(tc.tableID)
+			require.NoError(t, err)
+			ex, dbURI := newExecutorWithIntegerTable(t, 0, WithMaxDescriptionLength(10))
+
+			bs, err := ex.NewBlockScope(ctx, 1)
+			require.NoError(t, err)
+			tc.assertErr(t, bs.SetTableDescription(ctx, id, tc.caller, tc.description))
+			require.NoError(t, bs.Commit())
+			require.NoError(t, bs.Close())
+			require.NoError(t, ex.Close(ctx))
+
+			require.Equal(t, tc.expectedDescription, tableReadString(t, dbURI, descriptionQuery))
+		})
+	}
+}
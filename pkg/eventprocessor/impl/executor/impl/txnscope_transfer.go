@@ -7,6 +7,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
 )
@@ -16,7 +18,7 @@ func (ts *txnScope) executeTransferEvent(
 	e *ethereum.ContractTransferTable,
 ) (eventExecutionResult, error) {
 	if e.TableId == nil {
-		return eventExecutionResult{Error: &tableIDIsEmpty}, nil
+		return eventExecutionResult{Error: &tableIDIsEmpty, ErrorCode: eventprocessor.ErrorCodeMissingTableID}, nil
 	}
 
 	tableID := tables.TableID(*e.TableId)
@@ -24,25 +26,25 @@ func (ts *txnScope) executeTransferEvent(
 		var dbErr *errQueryExecution
 		if errors.As(err, &dbErr) {
 			err := fmt.Sprintf("change table owner execution failed (code: %s, msg: %s)", dbErr.Code, dbErr.Msg)
-			return eventExecutionResult{Error: &err}, nil
+			return eventExecutionResult{Error: &err, ErrorCode: dbErr.ErrorCode}, nil
 		}
 		return eventExecutionResult{}, fmt.Errorf("executing change table owner: %s", err)
 	}
 
 	privileges := tableland.Privileges{tableland.PrivInsert, tableland.PrivUpdate, tableland.PrivDelete}
-	if err := ts.executeRevokePrivilegesTx(ctx, tableID, e.From, privileges); err != nil {
+	if err := ts.executeRevokePrivilegesTx(ctx, tableID, []common.Address{e.From}, privileges); err != nil {
 		var dbErr *errQueryExecution
 		if errors.As(err, &dbErr) {
 			err := fmt.Sprintf("revoke privileges execution failed (code: %s, msg: %s)", dbErr.Code, dbErr.Msg)
-			return eventExecutionResult{Error: &err}, nil
+			return eventExecutionResult{Error: &err, ErrorCode: dbErr.ErrorCode}, nil
 		}
 		return eventExecutionResult{}, fmt.Errorf("executing revoke privileges: %s", err)
 	}
-	if err := ts.executeGrantPrivilegesTx(ctx, tableID, e.To, privileges); err != nil {
+	if err := ts.executeGrantPrivilegesTx(ctx, tableID, []common.Address{e.To}, privileges); err != nil {
 		var dbErr *errQueryExecution
 		if errors.As(err, &dbErr) {
 			err := fmt.Sprintf("grant privileges execution failed (code: %s, msg: %s)", dbErr.Code, dbErr.Msg)
-			return eventExecutionResult{Error: &err}, nil
+			return eventExecutionResult{Error: &err, ErrorCode: dbErr.ErrorCode}, nil
 		}
 		return eventExecutionResult{}, fmt.Errorf("executing grant privileges: %s", err)
 	}
@@ -50,6 +52,37 @@ func (ts *txnScope) executeTransferEvent(
 	return eventExecutionResult{TableID: &tableID}, nil
 }
 
+// transferOwnership hands over the ownership of a table owned by caller to newOwner. After checking
+// that the table exists and is owned by caller, it follows the same path as a transfer event.
+func (ts *txnScope) transferOwnership(
+	ctx context.Context,
+	id tables.TableID,
+	caller common.Address,
+	newOwner common.Address,
+) error {
+	if newOwner == (common.Address{}) && !ts.scopeVars.AllowTableLocking {
+		return &executor.ErrInvalidOwner{Owner: newOwner}
+	}
+
+	if _, err := ts.checkTableOwner(ctx, id, caller); err != nil {
+		return err
+	}
+
+	res, err := ts.executeTransferEvent(ctx, &ethereum.ContractTransferTable{
+		From:    caller,
+		To:      newOwner,
+		TableId: id.ToBigInt(),
+	})
+	if err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return &errQueryExecution{Code: "TRANSFER", Msg: *res.Error, ErrorCode: res.ErrorCode}
+	}
+
+	return nil
+}
+
 // changeTableOwner changes the owner of the table in the registry table.
 func (ts *txnScope) changeTableOwner(
 	ctx context.Context,
@@ -64,8 +97,9 @@ func (ts *txnScope) changeTableOwner(
 	); err != nil {
 		if code, ok := isErrCausedByQuery(err); ok {
 			return &errQueryExecution{
-				Code: "SQLITE_" + code,
-				Msg:  err.Error(),
+				Code:      "SQLITE_" + code,
+				Msg:       err.Error(),
+				ErrorCode: sqliteErrorCode(err),
 			}
 		}
 		return fmt.Errorf("updating table owner: %s", err)
@@ -8,8 +8,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
+	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
 )
 
@@ -55,6 +57,105 @@ func TestTransfer(t *testing.T) {
 		))
 }
 
+func TestBlockScopeTransferOwnership(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	id, err := tables.NewTableID("100")
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	newOwner := common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2")
+
+	countRegistry := func(t *testing.T, dbURI string, owner common.Address) int {
+		return tableReadInteger(t, dbURI, fmt.Sprintf(
+			"select count(1) from registry where controller = '%s' and id = 100 and chain_id = %d",
+			owner.Hex(), chainID))
+	}
+	readPrivileges := func(t *testing.T, dbURI string, owner common.Address) int {
+		return tableReadInteger(t, dbURI, fmt.Sprintf(
+			"select privileges from system_acl where controller = '%s' and table_id = 100 and chain_id = %d",
+			owner.Hex(), chainID))
+	}
+	allPrivileges := tableland.PrivInsert.Bitfield | tableland.PrivUpdate.Bitfield | tableland.PrivDelete.Bitfield
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		caller common.Address
+		to     common.Address
+
+		assertErr          func(t *testing.T, err error)
+		expectedOwner      common.Address
+		expectedPrivileges map[common.Address]int
+	}{
+		{
+			name:   "owner",
+			caller: owner,
+			to:     newOwner,
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			expectedOwner:      newOwner,
+			expectedPrivileges: map[common.Address]int{owner: 0, newOwner: allPrivileges},
+		},
+		{
+			name:   "non-owner",
+			caller: newOwner,
+			to:     newOwner,
+			assertErr: func(t *testing.T, err error) {
+				var dbErr *errQueryExecution
+				require.ErrorAs(t, err, &dbErr)
+				require.Equal(t, "ACL_NOT_OWNER", dbErr.Code)
+			},
+			expectedOwner:      owner,
+			expectedPrivileges: map[common.Address]int{owner: allPrivileges},
+		},
+		{
+			name:   "zero address",
+			caller: owner,
+			to:     common.Address{},
+			assertErr: func(t *testing.T, err error) {
+				var invalidErr *executor.ErrInvalidOwner
+				require.ErrorAs(t, err, &invalidErr)
+			},
+			expectedOwner:      owner,
+			expectedPrivileges: map[common.Address]int{owner: allPrivileges},
+		},
+		{
+			name:   "lock table",
+			opts:   []Option{WithTableLocking(true)},
+			caller: owner,
+			to:     common.Address{},
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+			expectedOwner:      common.Address{},
+			expectedPrivileges: map[common.Address]int{owner: 0},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ex, dbURI := newExecutorWithIntegerTable(t, 0, tc.opts...)
+
+			bs, err := ex.NewBlockScope(ctx, 1)
+			require.NoError(t, err)
+			tc.assertErr(t, bs.TransferOwnership(ctx, id, tc.caller, tc.to))
+			require.NoError(t, bs.Commit())
+			require.NoError(t, bs.Close())
+			require.NoError(t, ex.Close(ctx))
+
+			require.Equal(t, 1, countRegistry(t, dbURI, tc.expectedOwner))
+			for controller, privileges := range tc.expectedPrivileges {
+				require.Equal(t, privileges, readPrivileges(t, dbURI, controller))
+			}
+		})
+	}
+}
+
 func assertExecTxnWithTransfer(t *testing.T, bs executor.BlockScope, tableID int, from string, to string) {
 	t.Helper()
 
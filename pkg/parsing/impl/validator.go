@@ -19,12 +19,37 @@ type QueryValidator struct {
 	createTableNameRegEx *regexp.Regexp
 	queryTableNameRegEx  *regexp.Regexp
 	config               *parsing.Config
+	gatewayConfig        *parsing.GatewayConfig
 }
 
 var _ parsing.SQLValidator = (*QueryValidator)(nil)
 
 // New returns a Tableland query validator.
 func New(systemTablePrefixes []string, opts ...parsing.Option) (parsing.SQLValidator, error) {
+	return newQueryValidator(systemTablePrefixes, opts, parsing.DefaultGatewayConfig())
+}
+
+// NewGateway returns a Tableland query validator for queries received by the gateway, which
+// also enforces the provided gateway constraints. It must not be used to execute chain events.
+func NewGateway(
+	systemTablePrefixes []string,
+	opts []parsing.Option,
+	gatewayOpts ...parsing.GatewayOption,
+) (parsing.SQLValidator, error) {
+	gatewayConfig := parsing.DefaultGatewayConfig()
+	for _, o := range gatewayOpts {
+		if err := o(gatewayConfig); err != nil {
+			return nil, fmt.Errorf("applying provided gateway option: %s", err)
+		}
+	}
+	return newQueryValidator(systemTablePrefixes, opts, gatewayConfig)
+}
+
+func newQueryValidator(
+	systemTablePrefixes []string,
+	opts []parsing.Option,
+	gatewayConfig *parsing.GatewayConfig,
+) (parsing.SQLValidator, error) {
 	config := parsing.DefaultConfig()
 	for _, o := range opts {
 		if err := o(config); err != nil {
@@ -41,6 +66,7 @@ func New(systemTablePrefixes []string, opts ...parsing.Option) (parsing.SQLValid
 		createTableNameRegEx: createTableNameRegEx,
 		queryTableNameRegEx:  queryTableNameRegEx,
 		config:               config,
+		gatewayConfig:        gatewayConfig,
 	}, nil
 }
 
@@ -66,7 +92,7 @@ func (pp *QueryValidator) ValidateCreateTable(query string, chainID tableland.Ch
 		return nil, fmt.Errorf("create table name is not valid: %w", err)
 	}
 
-	if hasPrefix(validTable.Prefix(), pp.systemTablePrefixes) {
+	if pp.hasSystemTablePrefix(validTable.Prefix()) {
 		return nil, &parsing.ErrPrefixTableName{Prefix: validTable.Prefix()}
 	}
 
@@ -74,14 +100,60 @@ func (pp *QueryValidator) ValidateCreateTable(query string, chainID tableland.Ch
 		return nil, &parsing.ErrInvalidTableName{}
 	}
 
+	cols := columnDefinitions(node)
+	if err := pp.checkEnabledTypes(cols); err != nil {
+		return nil, err
+	}
+	structureHash := parsing.StructureHash(cols)
+	if pp.gatewayConfig.OrderInsensitiveStructureHash {
+		structureHash = parsing.OrderInsensitiveStructureHash(cols)
+	}
+
 	return &createStmt{
 		chainID:       chainID,
 		cNode:         node,
-		structureHash: node.StructureHash(),
-		prefix:        validTable.Prefix(),
+		structureHash: structureHash,
+		// The prefix keeps its original casing: it ends up in the registry and in the
+		// physical table name, both of which feed the state hash.
+		prefix: validTable.Prefix(),
 	}, nil
 }
 
+func (pp *QueryValidator) checkEnabledTypes(cols []parsing.ColumnDefinition) error {
+	if len(pp.gatewayConfig.EnabledTypes) == 0 {
+		return nil
+	}
+	for _, col := range cols {
+		enabled := false
+		for _, typ := range pp.gatewayConfig.EnabledTypes {
+			if strings.EqualFold(col.Type, typ) {
+				enabled = true
+				break
+			}
+		}
+		if !enabled {
+			return &parsing.ErrTypeDisabled{Type: col.Type}
+		}
+	}
+	return nil
+}
+
+func columnDefinitions(node *sqlparser.CreateTable) []parsing.ColumnDefinition {
+	cols := make([]parsing.ColumnDefinition, len(node.ColumnsDef))
+	for i, colDef := range node.ColumnsDef {
+		cols[i] = parsing.ColumnDefinition{
+			Name: colDef.Column.String(),
+			Type: colDef.Type,
+		}
+		for _, constraint := range colDef.Constraints {
+			if _, ok := constraint.(*sqlparser.ColumnConstraintNotNull); ok {
+				cols[i].NotNull = true
+			}
+		}
+	}
+	return cols
+}
+
 // ValidateMutatingQuery validates a mutating-query, and a list of mutating statements
 // contained in it.
 func (pp *QueryValidator) ValidateMutatingQuery(
@@ -108,6 +180,7 @@ func (pp *QueryValidator) ValidateMutatingQuery(
 	// do the write/grant-query validation in each of them. Also, check
 	// that each statement reference always the same table.
 	var targetTable, refTable *sqlparser.ValidatedTable
+	var writeKind string
 	for i := range ast.Statements {
 		if ast.Errors[i] != nil {
 			return nil, fmt.Errorf("non sysntax error in %d-th statement: %w", i, ast.Errors[i])
@@ -121,6 +194,15 @@ func (pp *QueryValidator) ValidateMutatingQuery(
 				return nil, fmt.Errorf("validating write-query: %w", err)
 			}
 
+			if !pp.gatewayConfig.AllowMixedOps {
+				kind := writeStatementKind(s)
+				if writeKind == "" {
+					writeKind = kind
+				} else if writeKind != kind {
+					return nil, &parsing.ErrMixedOperations{Op1: writeKind, Op2: kind}
+				}
+			}
+
 		case sqlparser.GrantOrRevokeStatement:
 			refTable, err = pp.validateGrantQuery(s)
 			if err != nil {
@@ -132,7 +214,7 @@ func (pp *QueryValidator) ValidateMutatingQuery(
 
 		if targetTable == nil {
 			targetTable = refTable
-		} else if targetTable.Name() != refTable.Name() {
+		} else if !pp.sameIdentifier(targetTable.Name(), refTable.Name()) {
 			return nil, &parsing.ErrMultiTableReference{Ref1: targetTable.Name(), Ref2: refTable.Name()}
 		}
 	}
@@ -183,6 +265,24 @@ func (pp *QueryValidator) ValidateMutatingQuery(
 	return ret, nil
 }
 
+// ValidateGrant validates a query with a single GRANT or REVOKE statement.
+func (pp *QueryValidator) ValidateGrant(query string, chainID tableland.ChainID) (parsing.GrantStmt, error) {
+	stmts, err := pp.ValidateMutatingQuery(query, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, &parsing.ErrNoTopLevelGrant{}
+	}
+	grantStmt, ok := stmts[0].(parsing.GrantStmt)
+	if !ok {
+		return nil, &parsing.ErrNoTopLevelGrant{}
+	}
+
+	return grantStmt, nil
+}
+
 // ValidateReadQuery validates a read-query, and returns a structured representation of it.
 func (pp *QueryValidator) ValidateReadQuery(query string) (parsing.ReadStmt, error) {
 	if len(query) > pp.config.MaxReadQuerySize {
@@ -205,11 +305,54 @@ func (pp *QueryValidator) ValidateReadQuery(query string) (parsing.ReadStmt, err
 		return nil, errors.New("the query isn't a read-query")
 	}
 
+	if err := checkNoSchemaQualifiedNames(ast.Statements[0]); err != nil {
+		return nil, fmt.Errorf("no schema-qualified names: %w", err)
+	}
+
+	if pp.config.RequireOrderByWithLimit {
+		if err := checkLimitWithOrderBy(ast.Statements[0]); err != nil {
+			return nil, fmt.Errorf("limit with order by: %w", err)
+		}
+	}
+
+	if len(pp.gatewayConfig.DeniedOperators) > 0 {
+		if err := checkNoDeniedOperators(ast.Statements[0], pp.gatewayConfig.DeniedOperators); err != nil {
+			return nil, fmt.Errorf("denied operators: %w", err)
+		}
+	}
+
 	return &readStmt{
 		statement: ast.Statements[0],
 	}, nil
 }
 
+// InjectReadFilter validates a read query, and returns it with the filter AND-ed into
+// its WHERE clause. A WHERE clause is added if the query doesn't have one.
+func (pp *QueryValidator) InjectReadFilter(query string, filter string) (string, error) {
+	stmt, err := pp.ValidateReadQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("validating read query: %w", err)
+	}
+
+	filterExpr, err := parseBooleanExpr(filter)
+	if err != nil {
+		return "", &parsing.ErrInvalidReadFilter{Filter: filter, Reason: err.Error()}
+	}
+
+	selectStmt := stmt.(*readStmt).statement.(*sqlparser.Select)
+	if selectStmt.Where == nil {
+		selectStmt.Where = sqlparser.NewWhere(sqlparser.WhereStr, filterExpr)
+	} else {
+		// Both sides are parenthesized, so an OR in the original clause can't bypass the filter.
+		selectStmt.Where = sqlparser.NewWhere(sqlparser.WhereStr, &sqlparser.AndExpr{
+			Left:  &sqlparser.ParenExpr{Expr: selectStmt.Where.Expr},
+			Right: &sqlparser.ParenExpr{Expr: filterExpr},
+		})
+	}
+
+	return selectStmt.String(), nil
+}
+
 type mutatingStmt struct {
 	node        sqlparser.Statement
 	prefix      string         // From {prefix}_{chainID}_{tableID} -> {prefix}
@@ -254,23 +397,27 @@ type writeStmt struct {
 
 var _ parsing.WriteStmt = (*writeStmt)(nil)
 
+func (ws *writeStmt) GetCanonicalQuery() string {
+	// The string representation of the AST has lowercased keywords and normalized whitespace.
+	return ws.node.String()
+}
+
+func (ws *writeStmt) GetReferencedTables() []string {
+	return []string{ws.dbTableName}
+}
+
 func (ws *writeStmt) AddWhereClause(whereClauses string) error {
 	// this does not apply to insert
 	if ws.Operation() == tableland.OpInsert {
 		return parsing.ErrCantAddWhereOnINSERT
 	}
 
-	// helper query to extract the where clause from the AST
-	helper, err := sqlparser.Parse("UPDATE helper SET foo = 'bar' WHERE " + whereClauses)
+	expr, err := ws.parsePolicyClause(whereClauses)
 	if err != nil {
-		return fmt.Errorf("parsing where clauses: %s", err)
+		return fmt.Errorf("parsing where clauses: %w", err)
 	}
 
-	if helper.Errors[0] != nil {
-		return fmt.Errorf("parsing where clauses: %s", err)
-	}
-
-	whereNode := helper.Statements[0].(*sqlparser.Update).Where
+	whereNode := sqlparser.NewWhere(sqlparser.WhereStr, expr)
 	if updateStmt, ok := ws.node.(*sqlparser.Update); ok {
 		updateStmt.AddWhereClause(whereNode)
 		return nil
@@ -284,6 +431,83 @@ func (ws *writeStmt) AddWhereClause(whereClauses string) error {
 	return nil
 }
 
+func (ws *writeStmt) BuildPolicyClause(clause string) (string, error) {
+	expr, err := ws.parsePolicyClause(clause)
+	if err != nil {
+		return "", err
+	}
+
+	return expr.String(), nil
+}
+
+// parsePolicyClause parses a policy clause as a WHERE expression, and checks that
+// it only references the target table. SQLite evaluates any scalar as a boolean in a WHERE
+// clause, so clauses such as "active" or "instr(owner, 'x')" are valid policies.
+func (ws *writeStmt) parsePolicyClause(clause string) (sqlparser.Expr, error) {
+	expr, err := parseWhereExpr(clause)
+	if err != nil {
+		return nil, &parsing.ErrInvalidPolicyClause{Clause: clause, Reason: err.Error()}
+	}
+
+	if err := sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		column, ok := node.(*sqlparser.Column)
+		if ok && column.TableRef != nil && column.TableRef.Name.String() != ws.dbTableName {
+			return true, fmt.Errorf("column %s doesn't belong to the target table", column.String())
+		}
+		return false, nil
+	}, expr); err != nil {
+		return nil, &parsing.ErrInvalidPolicyClause{Clause: clause, Reason: err.Error()}
+	}
+
+	return expr, nil
+}
+
+// parseBooleanExpr parses a clause with parseWhereExpr, and checks that it's a boolean expression.
+func parseBooleanExpr(clause string) (sqlparser.Expr, error) {
+	expr, err := parseWhereExpr(clause)
+	if err != nil {
+		return nil, err
+	}
+	if !isBooleanExpr(expr) {
+		return nil, errors.New("it isn't a boolean expression")
+	}
+	return expr, nil
+}
+
+// parseWhereExpr parses a clause as the WHERE of a helper query. The parser already
+// rejects subqueries and functions that aren't allowed, so on top of that we check
+// that the clause is a single expression.
+func parseWhereExpr(clause string) (sqlparser.Expr, error) {
+	helper, err := sqlparser.Parse("UPDATE helper SET foo = 'bar' WHERE " + clause)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(helper.Statements) != 1 {
+		return nil, errors.New("it must be a single expression")
+	}
+
+	updateStmt, ok := helper.Statements[0].(*sqlparser.Update)
+	if !ok || updateStmt.Where == nil {
+		return nil, errors.New("it must be a single expression")
+	}
+
+	return updateStmt.Where.Expr, nil
+}
+
+func isBooleanExpr(expr sqlparser.Expr) bool {
+	switch e := expr.(type) {
+	case *sqlparser.CmpExpr, *sqlparser.AndExpr, *sqlparser.OrExpr, *sqlparser.NotExpr,
+		*sqlparser.IsExpr, *sqlparser.IsNullExpr, *sqlparser.NotNullExpr, *sqlparser.BetweenExpr,
+		sqlparser.BoolValue:
+		return true
+	case *sqlparser.ParenExpr:
+		return isBooleanExpr(e.Expr)
+	default:
+		return false
+	}
+}
+
 func (ws *writeStmt) AddReturningClause() error {
 	// this does not apply to delete
 	if ws.Operation() == tableland.OpDelete {
@@ -305,6 +529,17 @@ func (ws *writeStmt) AddReturningClause() error {
 	return nil
 }
 
+func (ws *writeStmt) EstimateInsertedRows() int {
+	insert, ok := ws.node.(*sqlparser.Insert)
+	if !ok || insert.Select != nil {
+		return 0
+	}
+	if insert.DefaultValues {
+		return 1
+	}
+	return len(insert.Rows)
+}
+
 func (ws *writeStmt) CheckColumns(allowedColumns []string) error {
 	if ws.Operation() != tableland.OpUpdate {
 		return parsing.ErrCanOnlyCheckColumnsOnUPDATE
@@ -366,16 +601,88 @@ func (s *readStmt) GetQuery(resolver sqlparser.ReadStatementResolver) (string, e
 	return query, nil
 }
 
+func (s *readStmt) GetReferencedTables() []string {
+	var names []string
+	seen := map[string]struct{}{}
+	_ = sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		// Table nodes are also used for column qualifiers, which can be aliases,
+		// so only the tables in FROM and JOIN clauses are considered.
+		aliased, ok := node.(*sqlparser.AliasedTableExpr)
+		if !ok {
+			return false, nil
+		}
+		if table, ok := aliased.Expr.(*sqlparser.Table); ok {
+			name := strings.ToLower(table.String())
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+		return false, nil
+	}, s.statement)
+
+	return names
+}
+
+func (s *readStmt) IsOrdered() bool {
+	sel, ok := s.statement.(*sqlparser.Select)
+	return ok && len(sel.OrderBy) > 0
+}
+
 func (pp *QueryValidator) validateWriteQuery(stmt sqlparser.WriteStatement) (*sqlparser.ValidatedTable, error) {
-	if err := checkNoSystemTablesReferencing(stmt, pp.systemTablePrefixes); err != nil {
+	if err := pp.checkNoSystemTablesReferencing(stmt); err != nil {
 		return nil, fmt.Errorf("no system-table reference: %w", err)
 	}
 
+	if err := checkNoSchemaQualifiedNames(stmt); err != nil {
+		return nil, fmt.Errorf("no schema-qualified names: %w", err)
+	}
+
 	insertTable, err := sqlparser.ValidateTargetTable(stmt.GetTable())
 	if err != nil {
 		return nil, fmt.Errorf("table name is not valid: %w", err)
 	}
 
+	if insert, ok := stmt.(*sqlparser.Insert); ok && pp.gatewayConfig.CheckInsertColumnCount && len(insert.Columns) > 0 {
+		for _, row := range insert.Rows {
+			if len(row) != len(insert.Columns) {
+				return nil, &parsing.ErrColumnCountMismatch{
+					ColumnCount: len(insert.Columns),
+					ValueCount:  len(row),
+				}
+			}
+		}
+	}
+
+	if del, ok := stmt.(*sqlparser.Delete); ok && pp.gatewayConfig.RequireDeleteWhere && del.Where == nil {
+		return nil, &parsing.ErrUnconditionalDelete{}
+	}
+
+	if update, ok := stmt.(*sqlparser.Update); ok && pp.gatewayConfig.RequireUpdateWhere && update.Where == nil {
+		return nil, &parsing.ErrUnconditionalUpdate{}
+	}
+
+	if pp.gatewayConfig.DisallowNullLiterals {
+		if err := checkNoNullLiterals(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(pp.gatewayConfig.DeniedOperators) > 0 {
+		var where *sqlparser.Where
+		switch stmt := stmt.(type) {
+		case *sqlparser.Update:
+			where = stmt.Where
+		case *sqlparser.Delete:
+			where = stmt.Where
+		}
+		if where != nil {
+			if err := checkNoDeniedOperators(where, pp.gatewayConfig.DeniedOperators); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if insert, ok := stmt.(*sqlparser.Insert); ok && insert.Select != nil {
 		tables, err := sqlparser.ValidateTargetTables(insert.Select)
 		if err != nil {
@@ -397,15 +704,33 @@ func (pp *QueryValidator) validateWriteQuery(stmt sqlparser.WriteStatement) (*sq
 	return insertTable, nil
 }
 
+// writeStatementKind returns the kind of a write statement as its SQL keyword.
+func writeStatementKind(stmt sqlparser.WriteStatement) string {
+	switch stmt.(type) {
+	case *sqlparser.Insert:
+		return "insert"
+	case *sqlparser.Update:
+		return "update"
+	case *sqlparser.Delete:
+		return "delete"
+	default:
+		return fmt.Sprintf("%T", stmt)
+	}
+}
+
 func (pp *QueryValidator) validateGrantQuery(stmt sqlparser.GrantOrRevokeStatement) (*sqlparser.ValidatedTable, error) {
 	// check if roles are ETH addresses
 	for _, role := range stmt.GetRoles() {
 		addr := common.Address{}
 		if err := addr.UnmarshalText([]byte(role)); err != nil {
-			return nil, &parsing.ErrRoleIsNotAnEthAddress{}
+			return nil, &parsing.ErrInvalidRole{Role: role}
 		}
 	}
 
+	if err := checkNoSchemaQualifiedNames(stmt); err != nil {
+		return nil, fmt.Errorf("no schema-qualified names: %w", err)
+	}
+
 	table, err := sqlparser.ValidateTargetTable(stmt.GetTable())
 	if err != nil {
 		return nil, fmt.Errorf("table name is not valid: %w", err)
@@ -421,24 +746,157 @@ func checkNonEmptyStatement(parsed *sqlparser.AST) error {
 	return nil
 }
 
-func checkNoSystemTablesReferencing(stmt sqlparser.WriteStatement, systemTablePrefixes []string) error {
-	if hasPrefix(stmt.GetTable().String(), systemTablePrefixes) {
-		return &parsing.ErrSystemTableReferencing{}
+func (pp *QueryValidator) checkNoSystemTablesReferencing(stmt sqlparser.WriteStatement) error {
+	// A quoted identifier can be schema-qualified (e.g: "main.system_acl"),
+	// so every part of the name is checked.
+	for _, part := range strings.Split(stmt.GetTable().String(), ".") {
+		if pp.hasSystemTablePrefix(part) {
+			return &parsing.ErrSystemTableReferencing{}
+		}
 	}
 
 	return nil
 }
 
-func hasPrefix(s string, prefixes []string) bool {
-	for _, prefix := range prefixes {
-		if strings.HasPrefix(s, prefix) {
-			return true
+// checkNoNullLiterals checks that the VALUES rows of an INSERT and the set expressions
+// of an UPDATE don't contain an explicit NULL.
+func checkNoNullLiterals(stmt sqlparser.WriteStatement) error {
+	var nodes []sqlparser.Node
+	switch s := stmt.(type) {
+	case *sqlparser.Insert:
+		for _, row := range s.Rows {
+			nodes = append(nodes, row)
+		}
+	case *sqlparser.Update:
+		for _, expr := range s.Exprs {
+			nodes = append(nodes, expr.Expr)
+		}
+	}
+
+	return sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		if _, ok := node.(*sqlparser.NullValue); ok {
+			return true, &parsing.ErrNullNotAllowed{}
+		}
+		return false, nil
+	}, nodes...)
+}
+
+// defaultSchema is the only schema a table reference can be qualified with.
+const defaultSchema = "main"
+
+// checkNoSchemaQualifiedNames checks that no table in the statement is qualified with
+// a schema other than the default one. The parser only accepts schema-qualified names
+// as quoted identifiers (e.g: "temp.foo"), which are then stringified unquoted.
+func checkNoSchemaQualifiedNames(node sqlparser.Node) error {
+	return sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		table, ok := node.(*sqlparser.Table)
+		if !ok || table == nil {
+			return false, nil
+		}
+		name := table.String()
+		if i := strings.LastIndex(name, "."); i != -1 && !strings.EqualFold(name[:i], defaultSchema) {
+			return true, &parsing.ErrSchemaQualifiedName{Name: name}
+		}
+		return false, nil
+	}, node)
+}
+
+// checkLimitWithOrderBy checks that every SELECT in the statement, including subqueries,
+// that has a LIMIT also has an ORDER BY.
+func checkLimitWithOrderBy(node sqlparser.Node) error {
+	return sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		switch n := node.(type) {
+		case *sqlparser.Select:
+			if n != nil && n.Limit != nil && len(n.OrderBy) == 0 && !readsOrderedSubquery(n) {
+				return true, &parsing.ErrNonDeterministicLimit{}
+			}
+		case *sqlparser.CompoundSelect:
+			if n != nil && n.Limit != nil && len(n.OrderBy) == 0 {
+				return true, &parsing.ErrNonDeterministicLimit{}
+			}
 		}
+		return false, nil
+	}, node)
+}
+
+// readsOrderedSubquery checks if a SELECT only reads from a subquery with an ORDER BY, without
+// grouping or removing duplicates, so its rows keep the order of the subquery. That's the case of
+// the statements that paginate the results of a read query.
+func readsOrderedSubquery(s *sqlparser.Select) bool {
+	if s.Distinct != "" || len(s.GroupBy) > 0 {
+		return false
+	}
+	from, ok := s.From.(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return false
 	}
+	subquery, ok := from.Expr.(*sqlparser.Subquery)
+	if !ok {
+		return false
+	}
+	switch inner := subquery.Select.(type) {
+	case *sqlparser.Select:
+		return len(inner.OrderBy) > 0
+	case *sqlparser.CompoundSelect:
+		return len(inner.OrderBy) > 0
+	}
+	return false
+}
+
+// checkNoDeniedOperators checks that no unary, binary or comparison expression in node
+// uses one of the denied operators. Negated comparisons match the operator they negate.
+func checkNoDeniedOperators(node sqlparser.Node, denied []string) error {
+	return sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		var op string
+		switch n := node.(type) {
+		case *sqlparser.UnaryExpr:
+			if n != nil {
+				op = n.Operator
+			}
+		case *sqlparser.BinaryExpr:
+			if n != nil {
+				op = n.Operator
+			}
+		case *sqlparser.CmpExpr:
+			if n != nil {
+				op = n.Operator
+			}
+		}
+		if op == "" {
+			return false, nil
+		}
+		op = strings.ToLower(op)
+		for _, d := range denied {
+			if op == d || strings.TrimPrefix(op, "not ") == d {
+				return true, &parsing.ErrOperatorNotAllowed{Op: op}
+			}
+		}
+		return false, nil
+	}, node)
+}
 
+// hasSystemTablePrefix checks if s starts with any of the system table prefixes.
+func (pp *QueryValidator) hasSystemTablePrefix(s string) bool {
+	for _, prefix := range pp.systemTablePrefixes {
+		if pp.gatewayConfig.CaseInsensitiveIdentifiers {
+			if strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix)) {
+				return true
+			}
+		} else if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
 	return false
 }
 
+// sameIdentifier checks if a and b name the same table.
+func (pp *QueryValidator) sameIdentifier(a, b string) bool {
+	if pp.gatewayConfig.CaseInsensitiveIdentifiers {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
 type createStmt struct {
 	chainID       tableland.ChainID
 	cNode         *sqlparser.CreateTable
@@ -449,7 +907,7 @@ type createStmt struct {
 var _ parsing.CreateStmt = (*createStmt)(nil)
 
 func (cs *createStmt) GetRawQueryForTableID(id tables.TableID) (string, error) {
-	cs.cNode.Table.Name = sqlparser.Identifier(fmt.Sprintf("%s_%d_%s", cs.prefix, cs.chainID, id))
+	cs.cNode.Table.Name = sqlparser.Identifier(parsing.PhysicalTableName(cs.prefix, cs.chainID, id))
 	cs.cNode.StrictMode = true
 	return cs.cNode.String(), nil
 }
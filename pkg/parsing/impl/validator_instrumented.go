@@ -83,6 +83,27 @@ func (ip *InstrumentedSQLValidator) ValidateMutatingQuery(
 	return mutatingStmts, err
 }
 
+// ValidateGrant register metrics for its corresponding wrapped parser.
+func (ip *InstrumentedSQLValidator) ValidateGrant(
+	query string,
+	chainID tableland.ChainID,
+) (parsing.GrantStmt, error) {
+	log.Debug().Str("query", query).Msg("call ValidateGrant")
+	start := time.Now()
+	grantStmt, err := ip.parser.ValidateGrant(query, chainID)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ValidateGrant")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	ip.callCount.Add(context.Background(), 1, attributes...)
+	ip.latencyHistogram.Record(context.Background(), latency, attributes...)
+
+	return grantStmt, err
+}
+
 // ValidateReadQuery register metrics for its corresponding wrapped parser.
 func (ip *InstrumentedSQLValidator) ValidateReadQuery(query string) (parsing.ReadStmt, error) {
 	log.Debug().Str("query", query).Msg("call ValidateReadQuery")
@@ -100,3 +121,21 @@ func (ip *InstrumentedSQLValidator) ValidateReadQuery(query string) (parsing.Rea
 
 	return readStmt, err
 }
+
+// InjectReadFilter register metrics for its corresponding wrapped parser.
+func (ip *InstrumentedSQLValidator) InjectReadFilter(query string, filter string) (string, error) {
+	log.Debug().Str("query", query).Str("filter", filter).Msg("call InjectReadFilter")
+	start := time.Now()
+	filteredQuery, err := ip.parser.InjectReadFilter(query, filter)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("InjectReadFilter")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	ip.callCount.Add(context.Background(), 1, attributes...)
+	ip.latencyHistogram.Record(context.Background(), latency, attributes...)
+
+	return filteredQuery, err
+}
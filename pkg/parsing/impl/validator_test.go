@@ -1,6 +1,7 @@
 package impl_test
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -76,6 +77,18 @@ func TestReadRunSQL(t *testing.T) {
 			expErrType: ptr2ErrEmptyStatement(),
 		},
 
+		// Schema-qualified table names.
+		{
+			name:       "schema-qualified table",
+			query:      `select * from "pg_catalog.pg_tables"`,
+			expErrType: ptr2ErrSchemaQualifiedName(),
+		},
+		{
+			name:       "schema-qualified table in subquery",
+			query:      `select * from foo_1 where a in (select b from "temp.zoo_5")`,
+			expErrType: ptr2ErrSchemaQualifiedName(),
+		},
+
 		// Check no FROM SHARE/UPDATE
 		{
 			name:       "for share",
@@ -323,6 +336,34 @@ func TestWriteQuery(t *testing.T) {
 			query:      "delete from registry",
 			expErrType: ptr2ErrSystemTableReferencing(),
 		},
+		{
+			name:       "update quoted system table",
+			query:      `update "system_Tables" set a=1`,
+			expErrType: ptr2ErrSystemTableReferencing(),
+		},
+		{
+			name:       "delete schema-qualified system table",
+			query:      `delete from "public.system_tables"`,
+			expErrType: ptr2ErrSystemTableReferencing(),
+		},
+		{
+			name:       "insert schema-qualified system table",
+			query:      `insert into "main.system_tables" values ('foo')`,
+			expErrType: ptr2ErrSystemTableReferencing(),
+		},
+		{
+			name:       "insert schema-qualified table",
+			query:      `insert into "temp.foo_4_100" values (1)`,
+			expErrType: ptr2ErrSchemaQualifiedName(),
+		},
+		{
+			name:       "quoted user table",
+			query:      `delete from "foo_4_100"`,
+			tableID:    big.NewInt(100),
+			chainID:    4,
+			namePrefix: "foo",
+			expErrType: nil,
+		},
 
 		// Check non-deterministic functions.
 		{
@@ -377,7 +418,21 @@ func TestWriteQuery(t *testing.T) {
 			query:      "grant insert, update, delete on a_10 to 'role'",
 			tableID:    big.NewInt(10),
 			namePrefix: "a",
-			expErrType: ptr2ErrRoleIsNotAnEthAddress(),
+			expErrType: ptr2ErrInvalidRole(),
+		},
+		{
+			name:       "grant statement short address",
+			query:      "grant insert on a_10 to '0xd43c59d5694ec111eb9e986c233200b142495'",
+			tableID:    big.NewInt(10),
+			namePrefix: "a",
+			expErrType: ptr2ErrInvalidRole(),
+		},
+		{
+			name:       "grant statement address without prefix",
+			query:      "grant insert on a_10 to 'd43c59d5694ec111eb9e986c233200b14249558d'",
+			tableID:    big.NewInt(10),
+			namePrefix: "a",
+			expErrType: ptr2ErrInvalidRole(),
 		},
 	}
 
@@ -548,6 +603,73 @@ func TestCreateTableChecks(t *testing.T) {
 	}
 }
 
+func TestGetAcceptedTypes(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+
+	names := make(map[string]struct{})
+	for _, typ := range parsing.GetAcceptedTypes() {
+		names[typ.Name] = struct{}{}
+
+		// Every listed type must be accepted by the validator.
+		_, err := parser.ValidateCreateTable(fmt.Sprintf("create table foo_1337 (a %s)", typ.Name), 1337)
+		require.NoError(t, err, "type %s", typ.Name)
+	}
+	require.Contains(t, names, "int")
+	require.Contains(t, names, "text")
+	require.NotContains(t, names, "money")
+
+	_, err := parser.ValidateCreateTable("create table foo_1337 (a money)", 1337)
+	require.Error(t, err)
+}
+
+func TestAcceptedTypeCategories(t *testing.T) {
+	t.Parallel()
+
+	types := parsing.GetAcceptedTypes()
+	names := make([]string, len(types))
+	for i, typ := range types {
+		require.NotEmpty(t, typ.Category, "type %s", typ.Name)
+		require.True(t, typ.NullableByDefault, "type %s", typ.Name)
+		names[i] = typ.Name
+	}
+	require.Equal(t, []string{"int", "integer", "text", "blob"}, names)
+	require.Equal(t, names, parsing.GetAcceptedTypeNames())
+}
+
+func TestEnabledTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all accepted types enabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateCreateTable("create table foo_1337 (a int, b integer, c text, d blob)", 1337)
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled type", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithEnabledTypes("int", "INTEGER", "text"))
+		_, err := parser.ValidateCreateTable("create table foo_1337 (a int, b integer, c text)", 1337)
+		require.NoError(t, err)
+
+		_, err = parser.ValidateCreateTable("create table foo_1337 (c text, d blob)", 1337)
+		errTypeDisabled := ptr2ErrTypeDisabled()
+		require.ErrorAs(t, err, errTypeDisabled)
+		require.Equal(t, "blob", (*errTypeDisabled).Type)
+	})
+
+	t.Run("not accepted type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parser.NewGateway([]string{"system_", "registry"}, nil, parsing.WithEnabledTypes("uuid"))
+		require.Error(t, err)
+	})
+}
+
 func TestCreateTableResult(t *testing.T) {
 	t.Parallel()
 
@@ -630,6 +752,448 @@ func TestCreateTableResult(t *testing.T) {
 	}
 }
 
+func TestStructureHash(t *testing.T) {
+	t.Parallel()
+
+	cols := []parsing.ColumnDefinition{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "text"},
+	}
+	// echo -n id:INTEGER,name:TEXT | shasum -a 256
+	expHash := "f77c5742a571a180cab258042665c6cf3d172f62913a75791d4819563ee7f4e1"
+	require.Equal(t, expHash, parsing.StructureHash(cols))
+
+	parser := newParser(t, []string{"system_", "registry"})
+	cs, err := parser.ValidateCreateTable("create table foo_1337 (id integer, name text)", 1337)
+	require.NoError(t, err)
+	require.Equal(t, expHash, cs.GetStructureHash())
+}
+
+func TestOrderInsensitiveStructureHash(t *testing.T) {
+	t.Parallel()
+
+	query1 := "create table foo_1337 (id integer, name text)"
+	query2 := "create table foo_1337 (name text, id integer)"
+
+	structureHashes := func(t *testing.T, opts ...parsing.GatewayOption) (string, string) {
+		t.Helper()
+		parser := newGatewayParser(t, []string{"system_", "registry"}, opts...)
+		cs1, err := parser.ValidateCreateTable(query1, 1337)
+		require.NoError(t, err)
+		cs2, err := parser.ValidateCreateTable(query2, 1337)
+		require.NoError(t, err)
+		return cs1.GetStructureHash(), cs2.GetStructureHash()
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		hash1, hash2 := structureHashes(t)
+		require.NotEqual(t, hash1, hash2)
+	})
+
+	t.Run("order insensitive", func(t *testing.T) {
+		t.Parallel()
+		hash1, hash2 := structureHashes(t, parsing.WithOrderInsensitiveStructureHash(true))
+		require.Equal(t, hash1, hash2)
+		// echo -n id:INTEGER,name:TEXT | shasum -a 256
+		require.Equal(t, "f77c5742a571a180cab258042665c6cf3d172f62913a75791d4819563ee7f4e1", hash1)
+	})
+}
+
+func TestIdentifiersCaseFolding(t *testing.T) {
+	t.Parallel()
+
+	prefixes := []string{"system_", "registry"}
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			query     string
+			expPrefix string
+			expRawQry string
+		}{
+			{"create table foo_1337 (bar int)", "foo", "create table foo_1337_1 (bar int) strict"},
+			{"CREATE TABLE Foo_1337 (bar INT)", "Foo", "create table Foo_1337_1 (bar int) strict"},
+			{`create table "FOO_1337" (bar int)`, "FOO", "create table FOO_1337_1 (bar int) strict"},
+		}
+		for _, parser := range []parsing.SQLValidator{
+			newParser(t, prefixes),
+			newGatewayParser(t, prefixes, parsing.WithCaseInsensitiveIdentifiers(true)),
+		} {
+			for _, tc := range tests {
+				cs, err := parser.ValidateCreateTable(tc.query, 1337)
+				require.NoError(t, err)
+				require.Equal(t, tc.expPrefix, cs.GetPrefix())
+				rq, err := cs.GetRawQueryForTableID(tables.TableID(*big.NewInt(1)))
+				require.NoError(t, err)
+				require.Equal(t, tc.expRawQry, rq)
+			}
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			query       string
+			expPrefix   string
+			expDBTbName string
+		}{
+			{"insert into foo_1337_1 values (1)", "foo", "foo_1337_1"},
+			{"INSERT INTO Foo_1337_1 VALUES (1)", "Foo", "Foo_1337_1"},
+			{`insert into "FOO_1337_1" values (1)`, "FOO", "FOO_1337_1"},
+		}
+		for _, parser := range []parsing.SQLValidator{
+			newParser(t, prefixes),
+			newGatewayParser(t, prefixes, parsing.WithCaseInsensitiveIdentifiers(true)),
+		} {
+			for _, tc := range tests {
+				mss, err := parser.ValidateMutatingQuery(tc.query, 1337)
+				require.NoError(t, err)
+				for _, ms := range mss {
+					require.Equal(t, tc.expPrefix, ms.GetPrefix())
+					require.Equal(t, tc.expDBTbName, ms.GetDBTableName())
+				}
+			}
+		}
+	})
+
+	// The parser that executes chain events compares identifiers as written, like it always did.
+	t.Run("case sensitive", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, prefixes)
+
+		_, err := parser.ValidateCreateTable(`create table "SYSTEM_test_1337" (foo int)`, 1337)
+		require.NoError(t, err)
+		_, err = parser.ValidateCreateTable("create table Registry_1337 (foo int)", 1337)
+		require.NoError(t, err)
+		_, err = parser.ValidateMutatingQuery(`insert into "System_foo_1337_1" values ('foo')`, 1337)
+		require.NoError(t, err)
+
+		_, err = parser.ValidateMutatingQuery("insert into foo_1337_1 values (1); delete from FOO_1337_1", 1337)
+		require.ErrorAs(t, err, ptr2ErrMultiTableReference())
+	})
+
+	t.Run("case insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, prefixes, parsing.WithCaseInsensitiveIdentifiers(true))
+
+		_, err := parser.ValidateCreateTable(`create table "SYSTEM_test_1337" (foo int)`, 1337)
+		require.ErrorAs(t, err, ptr2ErrPrefixTableName())
+		_, err = parser.ValidateCreateTable("create table Registry_1337 (foo int)", 1337)
+		require.ErrorAs(t, err, ptr2ErrPrefixTableName())
+		_, err = parser.ValidateMutatingQuery(`insert into "System_foo_1337_1" values ('foo')`, 1337)
+		require.ErrorAs(t, err, ptr2ErrSystemTableReferencing())
+
+		// Every statement of the batch targets the table as it's named in the first one.
+		mss, err := parser.ValidateMutatingQuery("insert into foo_1337_1 values (1); delete from FOO_1337_1", 1337)
+		require.NoError(t, err)
+		for _, ms := range mss {
+			require.Equal(t, "foo", ms.GetPrefix())
+			require.Equal(t, "foo_1337_1", ms.GetDBTableName())
+		}
+	})
+}
+
+func TestInsertColumnCount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1)", 4)
+		require.NoError(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithInsertColumnCountCheck(true))
+
+		_, err := parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1, 2), (3, 4)", 4)
+		require.NoError(t, err)
+
+		_, err = parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1)", 4)
+		require.ErrorAs(t, err, ptr2ErrColumnCountMismatch())
+
+		_, err = parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1, 2), (3, 4, 5)", 4)
+		require.ErrorAs(t, err, ptr2ErrColumnCountMismatch())
+
+		// Without a column list the arity depends on the table, so it's left to the database.
+		_, err = parser.ValidateMutatingQuery("insert into duke_4_3333 values (1)", 4)
+		require.NoError(t, err)
+	})
+}
+
+func TestDisallowNullLiterals(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1, null)", 4)
+		require.NoError(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithDisallowNullLiterals(true))
+
+		_, err := parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1, null)", 4)
+		require.ErrorAs(t, err, ptr2ErrNullNotAllowed())
+
+		_, err = parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1, 2), (3, null)", 4)
+		require.ErrorAs(t, err, ptr2ErrNullNotAllowed())
+
+		_, err = parser.ValidateMutatingQuery("update duke_4_3333 set a = null where b = 1", 4)
+		require.ErrorAs(t, err, ptr2ErrNullNotAllowed())
+
+		_, err = parser.ValidateMutatingQuery("insert into duke_4_3333 (a, b) values (1, 2)", 4)
+		require.NoError(t, err)
+
+		// NULL is only rejected as a written value, not in filters.
+		_, err = parser.ValidateMutatingQuery("update duke_4_3333 set a = 1 where b is null", 4)
+		require.NoError(t, err)
+	})
+}
+
+func TestRequireDeleteWhere(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateMutatingQuery("delete from duke_4_3333", 4)
+		require.NoError(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithRequireDeleteWhere(true))
+
+		_, err := parser.ValidateMutatingQuery("delete from duke_4_3333", 4)
+		require.ErrorAs(t, err, ptr2ErrUnconditionalDelete())
+
+		_, err = parser.ValidateMutatingQuery("delete from duke_4_3333 where a=1", 4)
+		require.NoError(t, err)
+
+		_, err = parser.ValidateMutatingQuery("delete from duke_4_3333 where a=1; delete from duke_4_3333", 4)
+		require.ErrorAs(t, err, ptr2ErrUnconditionalDelete())
+	})
+}
+
+func TestRequireUpdateWhere(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateMutatingQuery("update duke_4_3333 set a=1", 4)
+		require.NoError(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithRequireUpdateWhere(true))
+
+		_, err := parser.ValidateMutatingQuery("update duke_4_3333 set a=1", 4)
+		require.ErrorAs(t, err, ptr2ErrUnconditionalUpdate())
+
+		_, err = parser.ValidateMutatingQuery("update duke_4_3333 set a=1 where b=2", 4)
+		require.NoError(t, err)
+
+		// Unconditional deletes are still allowed.
+		_, err = parser.ValidateMutatingQuery("delete from duke_4_3333", 4)
+		require.NoError(t, err)
+	})
+}
+
+func TestDeniedOperators(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateReadQuery("select * from foo_1 where name regexp '.*'")
+		require.NoError(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithDeniedOperators("REGEXP", "~"))
+
+		_, err := parser.ValidateReadQuery("select * from foo_1 where name regexp '.*'")
+		require.ErrorAs(t, err, ptr2ErrOperatorNotAllowed())
+
+		_, err = parser.ValidateReadQuery("select * from foo_1 where name not regexp '.*'")
+		require.ErrorAs(t, err, ptr2ErrOperatorNotAllowed())
+
+		_, err = parser.ValidateReadQuery("select * from foo_1 where ~a = 0")
+		require.ErrorAs(t, err, ptr2ErrOperatorNotAllowed())
+
+		_, err = parser.ValidateReadQuery("select * from foo_1 where a in (select a from bar_2 where b regexp 'x')")
+		require.ErrorAs(t, err, ptr2ErrOperatorNotAllowed())
+
+		_, err = parser.ValidateReadQuery("select * from foo_1 where name = 'x'")
+		require.NoError(t, err)
+
+		_, err = parser.ValidateMutatingQuery("update duke_4_3333 set a = 1 where name regexp '.*'", 4)
+		require.ErrorAs(t, err, ptr2ErrOperatorNotAllowed())
+
+		_, err = parser.ValidateMutatingQuery("delete from duke_4_3333 where name regexp '.*'", 4)
+		require.ErrorAs(t, err, ptr2ErrOperatorNotAllowed())
+
+		_, err = parser.ValidateMutatingQuery("delete from duke_4_3333 where name = 'x'", 4)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parser.NewGateway([]string{}, nil, parsing.WithDeniedOperators(" "))
+		require.Error(t, err)
+	})
+}
+
+func TestRequireOrderByWithLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateReadQuery("select * from foo_1 limit 10")
+		require.NoError(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"}, parsing.WithRequireOrderByWithLimit(true))
+
+		_, err := parser.ValidateReadQuery("select * from foo_1 limit 10")
+		require.ErrorAs(t, err, ptr2ErrNonDeterministicLimit())
+
+		_, err = parser.ValidateReadQuery("select * from foo_1 where a in (select a from bar_2 limit 1) order by id")
+		require.ErrorAs(t, err, ptr2ErrNonDeterministicLimit())
+
+		_, err = parser.ValidateReadQuery("select * from foo_1 order by id limit 10")
+		require.NoError(t, err)
+
+		_, err = parser.ValidateReadQuery("select * from foo_1")
+		require.NoError(t, err)
+
+		// A LIMIT on the rows of an ordered subquery is deterministic.
+		_, err = parser.ValidateReadQuery("select * from (select * from foo_1 order by id) limit 10 offset 10")
+		require.NoError(t, err)
+
+		_, err = parser.ValidateReadQuery("select * from (select * from foo_1) limit 10")
+		require.ErrorAs(t, err, ptr2ErrNonDeterministicLimit())
+
+		_, err = parser.ValidateReadQuery("select distinct a from (select * from foo_1 order by id) limit 10")
+		require.ErrorAs(t, err, ptr2ErrNonDeterministicLimit())
+	})
+}
+
+func TestSetReturningFunctions(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+
+	// Set-returning functions aren't in the parser's function allowlist, so they can't
+	// inflate the result of a read query.
+	for _, query := range []string{
+		"select generate_series(1,1000000)",
+		"select generate_series(1,1000000) from foo_1",
+		"select * from generate_series(1,1000000)",
+		"select json_each(a) from foo_1",
+		"select * from json_each('[1,2,3]')",
+		"select * from foo_1 where a in (select value from json_tree(b))",
+	} {
+		_, err := parser.ValidateReadQuery(query)
+		require.Error(t, err, query)
+	}
+
+	_, err := parser.ValidateReadQuery("select a, json_extract(b, '$.c') from foo_1 where a > 1")
+	require.NoError(t, err)
+}
+
+func TestInjectReadFilter(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		query    string
+		filter   string
+		expQuery string
+	}
+	tests := []testCase{
+		{
+			name:     "without where",
+			query:    "select * from foo_1",
+			filter:   "tenant = 'a'",
+			expQuery: "select * from foo_1 where tenant = 'a'",
+		},
+		{
+			name:     "with where",
+			query:    "select * from foo_1 where a = 1 or b = 2 limit 5",
+			filter:   "tenant = 'a'",
+			expQuery: "select * from foo_1 where (a = 1 or b = 2) and (tenant = 'a') limit 5",
+		},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(tc testCase) func(t *testing.T) {
+			return func(t *testing.T) {
+				t.Parallel()
+
+				parser := newParser(t, []string{"system_", "registry"})
+				query, err := parser.InjectReadFilter(tc.query, tc.filter)
+				require.NoError(t, err)
+				require.Equal(t, tc.expQuery, query)
+			}
+		}(it))
+	}
+
+	t.Run("invalid filters", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		for _, filter := range []string{
+			"1 = 1; delete from foo_1",
+			"1 = 1) or (1 = 1",
+			"tenant in (select tenant from bar_2)",
+			"random() > 0",
+			"tenant",
+		} {
+			_, err := parser.InjectReadFilter("select * from foo_1", filter)
+			var e *parsing.ErrInvalidReadFilter
+			require.ErrorAs(t, err, &e, filter)
+		}
+	})
+}
+
+func TestPhysicalTableName(t *testing.T) {
+	t.Parallel()
+
+	id, err := tables.NewTableID("42")
+	require.NoError(t, err)
+	require.Equal(t, "foo_1337_42", parsing.PhysicalTableName("foo", 1337, id))
+	require.Equal(t, "_1337_42", parsing.PhysicalTableName("", 1337, id))
+}
+
 func TestMaxReadQuerySize(t *testing.T) {
 	t.Parallel()
 
@@ -676,6 +1240,102 @@ func TestMaxWriteQuerySize(t *testing.T) {
 	})
 }
 
+func TestAllowMixedOps(t *testing.T) {
+	t.Parallel()
+
+	mixedQuery := "insert into foo_1337_1 values (1); delete from foo_1337_1 where a = 2"
+
+	t.Run("allowed by default", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newParser(t, []string{"system_", "registry"})
+		_, err := parser.ValidateMutatingQuery(mixedQuery, 1337)
+		require.NoError(t, err)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		t.Parallel()
+
+		parser := newGatewayParser(t, []string{"system_", "registry"}, parsing.WithAllowMixedOps(false))
+
+		stmts, err := parser.ValidateMutatingQuery(
+			"insert into foo_1337_1 values (1); insert into foo_1337_1 values (2)", 1337)
+		require.NoError(t, err)
+		require.Len(t, stmts, 2)
+
+		// Grants aren't write statements, so they can be part of any batch.
+		_, err = parser.ValidateMutatingQuery(
+			"insert into foo_1337_1 values (1); grant insert on foo_1337_1 to '0xd43c59d5694ec111eb9e986c233200b14249558d'", // nolint
+			1337)
+		require.NoError(t, err)
+
+		_, err = parser.ValidateMutatingQuery(mixedQuery, 1337)
+		var expErr *parsing.ErrMixedOperations
+		require.ErrorAs(t, err, &expErr)
+		require.Equal(t, "insert", expErr.Op1)
+		require.Equal(t, "delete", expErr.Op2)
+
+		// The same table check still applies.
+		_, err = parser.ValidateMutatingQuery(
+			"insert into foo_1337_1 values (1); insert into foo_1337_2 values (2)", 1337)
+		var multiTableErr *parsing.ErrMultiTableReference
+		require.ErrorAs(t, err, &multiTableErr)
+	})
+}
+
+func TestGetReferencedTables(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+
+		rs, err := parser.ValidateReadQuery(
+			"select * from foo_1 as f join bar_2 on f.a = bar_2.b join Zoo_3 on zoo_3.c = f.a where f.a in (select a from foo_1)")
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo_1", "bar_2", "zoo_3"}, rs.GetReferencedTables())
+	})
+
+	t.Run("write", func(t *testing.T) {
+		t.Parallel()
+
+		mss, err := parser.ValidateMutatingQuery("update foo_1337_1 set a = 1 where b = 2", 1337)
+		require.NoError(t, err)
+		require.Len(t, mss, 1)
+		ws, ok := mss[0].(parsing.WriteStmt)
+		require.True(t, ok)
+		require.Equal(t, []string{"foo_1337_1"}, ws.GetReferencedTables())
+	})
+}
+
+func TestReadStmtIsOrdered(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+
+	tests := []struct {
+		query   string
+		ordered bool
+	}{
+		{query: "select * from foo_1 order by a", ordered: true},
+		{query: "select a, count(*) from foo_1 group by a order by a", ordered: true},
+		{query: "select * from foo_1", ordered: false},
+		{query: "select * from (select * from foo_1 order by a)", ordered: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+
+			rs, err := parser.ValidateReadQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.ordered, rs.IsOrdered())
+		})
+	}
+}
+
 func TestGetWriteStatements(t *testing.T) {
 	t.Parallel()
 
@@ -774,6 +1434,97 @@ func TestGetGrantStatementRolesAndPrivileges(t *testing.T) {
 	}
 }
 
+func TestGrantAllIsRejected(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	// The parser doesn't support the ALL privileges shorthand, and queries are never rewritten
+	// to emulate it, so string literals that look like the shorthand are left untouched.
+	for _, query := range []string{
+		"grant all on a_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d'",
+		"revoke all privileges on a_1337_100 from '0xd43c59d5694ec111eb9e986c233200b14249558d'",
+		"insert into a_1337_100 values ('grant all on x');" +
+			"grant all on a_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d'",
+	} {
+		_, err := parser.ValidateMutatingQuery(query, 1337)
+		require.Error(t, err, query)
+	}
+
+	mss, err := parser.ValidateMutatingQuery("insert into a_1337_100 values ('grant all on x')", 1337)
+	require.NoError(t, err)
+	q, err := mss[0].GetQuery(nil)
+	require.NoError(t, err)
+	require.Contains(t, q, "'grant all on x'")
+}
+
+func TestValidateGrant(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		gs, err := parser.ValidateGrant(
+			"grant insert, update on a_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d'", 1337)
+		require.NoError(t, err)
+		require.Equal(t, tableland.OpGrant, gs.Operation())
+		require.Equal(t, "100", gs.GetTableID().String())
+		require.Equal(t, []common.Address{common.HexToAddress("0xd43c59d5694ec111eb9e986c233200b14249558d")}, gs.GetRoles())
+		require.ElementsMatch(t, tableland.Privileges{tableland.PrivInsert, tableland.PrivUpdate}, gs.GetPrivileges())
+	})
+
+	t.Run("unknown privilege", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parser.ValidateGrant(
+			"grant select on a_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d'", 1337)
+		var expErr *sqlparser.ErrSyntaxError
+		require.ErrorAs(t, err, &expErr)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parser.ValidateGrant("grant insert on a_1337_100 to 'role'", 1337)
+		var expErr *parsing.ErrInvalidRole
+		require.ErrorAs(t, err, &expErr)
+		require.Equal(t, "role", expErr.Role)
+
+		var deprecatedErr *parsing.ErrRoleIsNotAnEthAddress
+		require.ErrorAs(t, err, &deprecatedErr)
+	})
+
+	t.Run("not a grant", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parser.ValidateGrant("insert into a_1337_100 values (1)", 1337)
+		var expErr *parsing.ErrNoTopLevelGrant
+		require.ErrorAs(t, err, &expErr)
+
+		_, err = parser.ValidateGrant(
+			"grant insert on a_1337_100 to '0xd43c59d5694ec111eb9e986c233200b14249558d';"+
+				"revoke insert on a_1337_100 from '0xd43c59d5694ec111eb9e986c233200b14249558d'", 1337)
+		require.ErrorAs(t, err, &expErr)
+	})
+}
+
+func TestGrantStatementRolesChecksum(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	stmts, err := parser.ValidateMutatingQuery(
+		"grant insert on a_1337_100 to '0xD43C59D5694ec111eb9e986c233200b14249558d'", 1337)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	gs, ok := stmts[0].(parsing.GrantStmt)
+	require.True(t, ok)
+	roles := gs.GetRoles()
+	require.Len(t, roles, 1)
+	require.Equal(t, "0xD43C59d5694eC111Eb9e986C233200b14249558D", roles[0].Hex())
+}
+
 func TestWriteStatementAddWhereClause(t *testing.T) {
 	t.Parallel()
 
@@ -822,6 +1573,142 @@ func TestWriteStatementAddWhereClause(t *testing.T) {
 	}
 }
 
+func TestWriteStatementBuildPolicyClause(t *testing.T) {
+	t.Parallel()
+
+	type subTest struct {
+		name      string
+		clause    string
+		expClause string
+		expErr    bool
+	}
+	testCase := []subTest{
+		{
+			name:      "valid",
+			clause:    "bar > 1 AND (c IN (1, 2) OR foo_1337_10.baz = 'x')",
+			expClause: "bar > 1 and (c in (1, 2) or foo_1337_10.baz = 'x')",
+		},
+		{
+			name:   "second statement",
+			clause: "1 = 1; delete from foo_1337_10",
+			expErr: true,
+		},
+		{
+			name:   "unbalanced parenthesis",
+			clause: "1 = 1) or (1 = 1",
+			expErr: true,
+		},
+		{
+			name:   "subquery",
+			clause: "bar in (select bar from system_acl)",
+			expErr: true,
+		},
+		{
+			name:   "disallowed function",
+			clause: "random() > 0",
+			expErr: true,
+		},
+		{
+			name:   "other table column",
+			clause: "system_acl.controller = 'foo'",
+			expErr: true,
+		},
+		{
+			name:      "column",
+			clause:    "active",
+			expClause: "active",
+		},
+		{
+			name:      "literal",
+			clause:    "1",
+			expClause: "1",
+		},
+		{
+			name:      "function",
+			clause:    "instr(owner, 'x')",
+			expClause: "instr(owner, 'x')",
+		},
+		{
+			name:      "arithmetic",
+			clause:    "bar + 1",
+			expClause: "bar + 1",
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(tc subTest) func(t *testing.T) {
+			return func(t *testing.T) {
+				t.Parallel()
+
+				parser := newParser(t, []string{"system_", "registry"})
+				mss, err := parser.ValidateMutatingQuery("update foo_1337_10 set id = 1", 1337)
+				require.NoError(t, err)
+				require.Len(t, mss, 1)
+
+				ws, ok := mss[0].(parsing.WriteStmt)
+				require.True(t, ok)
+
+				clause, err := ws.BuildPolicyClause(tc.clause)
+				if tc.expErr {
+					var e *parsing.ErrInvalidPolicyClause
+					require.ErrorAs(t, err, &e)
+					require.Equal(t, tc.clause, e.Clause)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.expClause, clause)
+			}
+		}(tc))
+	}
+}
+
+func TestWriteStatementGetCanonicalQuery(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	canonicalQuery := func(query string) string {
+		mss, err := parser.ValidateMutatingQuery(query, 1337)
+		require.NoError(t, err)
+		require.Len(t, mss, 1)
+		ws, ok := mss[0].(parsing.WriteStmt)
+		require.True(t, ok)
+		return ws.GetCanonicalQuery()
+	}
+
+	q1 := canonicalQuery("insert into foo_1337_1 (a, b) values (1, 'bar')")
+	q2 := canonicalQuery("INSERT  INTO foo_1337_1(a,b)\n\tVALUES (1,'bar');")
+	require.Equal(t, q1, q2)
+	require.Equal(t, "insert into foo_1337_1 (a, b) values (1, 'bar')", q1)
+
+	// Semantic differences are kept.
+	require.NotEqual(t, q1, canonicalQuery("insert into foo_1337_1 (b, a) values ('bar', 1)"))
+}
+
+func TestWriteStatementEstimateInsertedRows(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	tests := []struct {
+		query string
+		rows  int
+	}{
+		{query: "insert into foo_1337_1 values (1)", rows: 1},
+		{query: "insert into foo_1337_1 values (1), (2), (3)", rows: 3},
+		{query: "insert into foo_1337_1 default values", rows: 1},
+		{query: "insert into foo_1337_1 select * from foo_1337_1", rows: 0},
+		{query: "update foo_1337_1 set a = 1", rows: 0},
+		{query: "delete from foo_1337_1 where a = 1", rows: 0},
+	}
+	for _, tc := range tests {
+		mss, err := parser.ValidateMutatingQuery(tc.query, 1337)
+		require.NoError(t, err, tc.query)
+		require.Len(t, mss, 1)
+		ws, ok := mss[0].(parsing.WriteStmt)
+		require.True(t, ok)
+		require.Equal(t, tc.rows, ws.EstimateInsertedRows(), tc.query)
+	}
+}
+
 func TestWriteStatementAddReturningClause(t *testing.T) {
 	t.Parallel()
 	t.Run("insert-add-returning", func(t *testing.T) {
@@ -885,6 +1772,13 @@ func newParser(t *testing.T, prefixes []string, opts ...parsing.Option) parsing.
 	return p
 }
 
+func newGatewayParser(t *testing.T, prefixes []string, opts ...parsing.GatewayOption) parsing.SQLValidator {
+	t.Helper()
+	p, err := parser.NewGateway(prefixes, nil, opts...)
+	require.NoError(t, err)
+	return p
+}
+
 // Helpers to have a pointer to pointer for generic test-case running.
 func ptr2ErrInvalidSyntax() **sqlparser.ErrSyntaxError {
 	var e *sqlparser.ErrSyntaxError
@@ -896,6 +1790,41 @@ func ptr2ErrEmptyStatement() **parsing.ErrEmptyStatement {
 	return &e
 }
 
+func ptr2ErrNonDeterministicLimit() **parsing.ErrNonDeterministicLimit {
+	var e *parsing.ErrNonDeterministicLimit
+	return &e
+}
+
+func ptr2ErrColumnCountMismatch() **parsing.ErrColumnCountMismatch {
+	var e *parsing.ErrColumnCountMismatch
+	return &e
+}
+
+func ptr2ErrNullNotAllowed() **parsing.ErrNullNotAllowed {
+	var e *parsing.ErrNullNotAllowed
+	return &e
+}
+
+func ptr2ErrUnconditionalDelete() **parsing.ErrUnconditionalDelete {
+	var e *parsing.ErrUnconditionalDelete
+	return &e
+}
+
+func ptr2ErrOperatorNotAllowed() **parsing.ErrOperatorNotAllowed {
+	var e *parsing.ErrOperatorNotAllowed
+	return &e
+}
+
+func ptr2ErrUnconditionalUpdate() **parsing.ErrUnconditionalUpdate {
+	var e *parsing.ErrUnconditionalUpdate
+	return &e
+}
+
+func ptr2ErrSchemaQualifiedName() **parsing.ErrSchemaQualifiedName {
+	var e *parsing.ErrSchemaQualifiedName
+	return &e
+}
+
 func ptr2ErrSystemTableReferencing() **parsing.ErrSystemTableReferencing {
 	var e *parsing.ErrSystemTableReferencing
 	return &e
@@ -946,8 +1875,8 @@ func ptr2ErrStatementIsNotSupported() **parsing.ErrStatementIsNotSupported {
 	return &e
 }
 
-func ptr2ErrRoleIsNotAnEthAddress() **parsing.ErrRoleIsNotAnEthAddress {
-	var e *parsing.ErrRoleIsNotAnEthAddress
+func ptr2ErrInvalidRole() **parsing.ErrInvalidRole {
+	var e *parsing.ErrInvalidRole
 	return &e
 }
 
@@ -955,3 +1884,8 @@ func ptr2ErrInsertWithSelectChainMistmatch() **parsing.ErrInsertWithSelectChainM
 	var e *parsing.ErrInsertWithSelectChainMistmatch
 	return &e
 }
+
+func ptr2ErrTypeDisabled() **parsing.ErrTypeDisabled {
+	var e *parsing.ErrTypeDisabled
+	return &e
+}
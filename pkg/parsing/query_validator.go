@@ -1,8 +1,12 @@
 package parsing
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/tablelandnetwork/sqlparser"
@@ -14,7 +18,8 @@ import (
 // a SugaredWriteStmt or a SugaredGrantStmt.
 type MutatingStmt interface {
 	// GetPrefix returns the prefix of the table, if any.  e.g: "insert into foo_4_100" -> "foo".
-	// Since the prefix is optional, it can return "".
+	// Since the prefix is optional, it can return "". The prefix keeps the casing used in the query,
+	// so compare it case-insensitively.
 	GetPrefix() string
 	// GetTableID returns the table id. "insert into foo_100" -> 100.
 	GetTableID() tables.TableID
@@ -22,7 +27,7 @@ type MutatingStmt interface {
 	// Operation returns the type of the operation.
 	Operation() tableland.Operation
 
-	// GetDBTableName returns the database table name.
+	// GetDBTableName returns the database table name as written in the query.
 	GetDBTableName() string
 
 	// GetQuery returns an executable stringification of a mutating statements with resolved custom functions.
@@ -36,6 +41,14 @@ type MutatingStmt interface {
 type ReadStmt interface {
 	// GetQuery returns an executable stringification of a mutating statements with resolved custom functions.
 	GetQuery(sqlparser.ReadStatementResolver) (string, error)
+
+	// GetReferencedTables returns the names of the tables the statement reads from,
+	// including joined tables and tables referenced in subqueries.
+	GetReferencedTables() []string
+
+	// IsOrdered returns true if the statement has an ORDER BY clause, so the order of its
+	// rows is stable between executions.
+	IsOrdered() bool
 }
 
 // WriteStmt is an already parsed write statement that satisfies all
@@ -45,14 +58,32 @@ type ReadStmt interface {
 type WriteStmt interface {
 	MutatingStmt
 
+	// GetCanonicalQuery returns a stable string representation of the statement. Equivalent
+	// statements that only differ in keyword casing or whitespace have the same canonical
+	// query, so it can be used for deduplication or as a cache key. GetQuery resolves custom
+	// functions in place, so after calling it the canonical query has them resolved too.
+	GetCanonicalQuery() string
+
+	// GetReferencedTables returns the names of the tables the statement touches,
+	// which for a write statement is only the target table.
+	GetReferencedTables() []string
+
 	// AddWhereClause adds where clauses to update statement.
 	AddWhereClause(string) error
 
 	// AddReturningClause add the RETURNING ctid clause to an insert or update statement.
 	AddReturningClause() error
 
+	// BuildPolicyClause validates a policy clause (e.g: WithCheck) and returns it rebuilt
+	// from its parsed expression, so it can be safely embedded in a SQL query.
+	BuildPolicyClause(string) (string, error)
+
 	// CheckColumns checks if a column that is not allowed is being touched on update.
 	CheckColumns([]string) error
+
+	// EstimateInsertedRows returns the number of rows an INSERT ... VALUES statement adds. It returns 0
+	// for other statements, including INSERT ... SELECT, since their effect depends on the table data.
+	EstimateInsertedRows() int
 }
 
 // GrantStmt is an already parsed grant statement that satisfies all
@@ -85,6 +116,104 @@ type CreateStmt interface {
 	GetPrefix() string
 }
 
+// PhysicalTableName returns the name of the database table backing a Tableland table,
+// in the {prefix}_{chainID}_{tableID} format. If the prefix is empty, the name starts
+// with an underscore (e.g: _1337_42).
+func PhysicalTableName(prefix string, chainID tableland.ChainID, id tables.TableID) string {
+	return fmt.Sprintf("%s_%d_%s", prefix, chainID, id)
+}
+
+// ColumnDefinition is a column name and its declared type, as defined
+// in a CREATE TABLE statement. NotNull isn't part of the structure hash.
+type ColumnDefinition struct {
+	Name    string
+	Type    string
+	NotNull bool
+}
+
+// StructureHash returns the structure fingerprint of a table with the provided
+// ordered set of columns. It's the hex encoded SHA-256 of the "name:TYPE" pairs
+// joined by commas, e.g: "name:TEXT,age:INT".
+func StructureHash(cols []ColumnDefinition) string {
+	return hashColumnPairs(columnPairs(cols))
+}
+
+// OrderInsensitiveStructureHash is like StructureHash, but the "name:TYPE" pairs are
+// sorted before hashing. Tables with the same columns declared in different order
+// have the same fingerprint.
+func OrderInsensitiveStructureHash(cols []ColumnDefinition) string {
+	pairs := columnPairs(cols)
+	sort.Strings(pairs)
+	return hashColumnPairs(pairs)
+}
+
+func columnPairs(cols []ColumnDefinition) []string {
+	pairs := make([]string, len(cols))
+	for i := range cols {
+		pairs[i] = fmt.Sprintf("%s:%s", cols[i].Name, strings.ToUpper(cols[i].Type))
+	}
+	return pairs
+}
+
+func hashColumnPairs(pairs []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(pairs, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// TypeCategory is the kind of values a column type stores.
+type TypeCategory string
+
+// Categories of the accepted column types.
+const (
+	TypeCategoryNumeric TypeCategory = "numeric"
+	TypeCategoryText    TypeCategory = "text"
+	TypeCategoryBinary  TypeCategory = "binary"
+)
+
+// AcceptedType is a column type accepted in CREATE TABLE statements.
+type AcceptedType struct {
+	// Name is the type name as written in a column definition, e.g: "int".
+	Name string
+	// Category is the kind of values the type stores.
+	Category TypeCategory
+	// NullableByDefault indicates if a column of this type accepts NULL values when
+	// it doesn't have a NOT NULL constraint.
+	NullableByDefault bool
+}
+
+// acceptedTypeGroups is the source of truth of the accepted column types, grouped by category.
+// The order of the groups and the types in them is the order in which they're listed.
+var acceptedTypeGroups = []struct {
+	category TypeCategory
+	types    []string
+}{
+	{TypeCategoryNumeric, []string{sqlparser.TypeIntStr, sqlparser.TypeIntegerStr}},
+	{TypeCategoryText, []string{sqlparser.TypeTextStr}},
+	{TypeCategoryBinary, []string{sqlparser.TypeBlobStr}},
+}
+
+// GetAcceptedTypes returns the column types accepted in CREATE TABLE statements.
+// The returned slice is a copy, so it can be modified by the caller.
+func GetAcceptedTypes() []AcceptedType {
+	var types []AcceptedType
+	for _, group := range acceptedTypeGroups {
+		for _, name := range group.types {
+			// SQLite columns accept NULL unless they have a NOT NULL constraint, whatever the type is.
+			types = append(types, AcceptedType{Name: name, Category: group.category, NullableByDefault: true})
+		}
+	}
+	return types
+}
+
+// GetAcceptedTypeNames returns the names of the column types accepted in CREATE TABLE statements.
+func GetAcceptedTypeNames() []string {
+	var names []string
+	for _, group := range acceptedTypeGroups {
+		names = append(names, group.types...)
+	}
+	return names
+}
+
 // SQLValidator parses and validate a SQL query for different supported scenarios.
 type SQLValidator interface {
 	// ValidateCreateTable validates a CREATE TABLE statement.
@@ -94,6 +223,12 @@ type SQLValidator interface {
 	// ValidateMutatingQuery validates a mutating-query, and a list of mutating statements
 	// contained in it.
 	ValidateMutatingQuery(query string, chainID tableland.ChainID) ([]MutatingStmt, error)
+	// ValidateGrant validates a query with a single GRANT or REVOKE statement, and returns
+	// a structured representation of it.
+	ValidateGrant(query string, chainID tableland.ChainID) (GrantStmt, error)
+	// InjectReadFilter validates a read query, and returns it with the filter, a boolean
+	// expression, AND-ed into its WHERE clause.
+	InjectReadFilter(query string, filter string) (string, error)
 }
 
 var (
@@ -139,6 +274,61 @@ func (e *ErrSystemTableReferencing) Error() string {
 	return strErr
 }
 
+// ErrSchemaQualifiedName is an error returned when a query references a table
+// qualified with a schema other than the default one.
+type ErrSchemaQualifiedName struct {
+	Name string
+}
+
+func (e *ErrSchemaQualifiedName) Error() string {
+	return fmt.Sprintf("the query references the schema-qualified table name '%s'", e.Name)
+}
+
+// ErrColumnCountMismatch is an error returned when an INSERT row has a different
+// number of values than the columns listed in the statement.
+type ErrColumnCountMismatch struct {
+	ColumnCount int
+	ValueCount  int
+}
+
+func (e *ErrColumnCountMismatch) Error() string {
+	return fmt.Sprintf("%d values for %d columns", e.ValueCount, e.ColumnCount)
+}
+
+// ErrNullNotAllowed is an error returned when a write statement has an explicit NULL
+// value and NULL literals aren't allowed.
+type ErrNullNotAllowed struct{}
+
+func (e *ErrNullNotAllowed) Error() string {
+	return "NULL values are not allowed in write statements"
+}
+
+// ErrUnconditionalDelete is an error returned when a DELETE statement doesn't have a
+// WHERE clause and that isn't allowed.
+type ErrUnconditionalDelete struct{}
+
+func (e *ErrUnconditionalDelete) Error() string {
+	return "delete statements must have a where clause"
+}
+
+// ErrUnconditionalUpdate is an error returned when an UPDATE statement doesn't have a
+// WHERE clause and that isn't allowed.
+type ErrUnconditionalUpdate struct{}
+
+func (e *ErrUnconditionalUpdate) Error() string {
+	return "update statements must have a where clause"
+}
+
+// ErrOperatorNotAllowed is an error returned when a query uses an operator that's
+// in the configured denylist.
+type ErrOperatorNotAllowed struct {
+	Op string
+}
+
+func (e *ErrOperatorNotAllowed) Error() string {
+	return fmt.Sprintf("operator %s is not allowed", e.Op)
+}
+
 // ErrStatementIsNotSupported is an error returned when the stament isn't
 // a SELECT, UPDATE, INSERT, DELETE, GRANT or REVOKE.
 type ErrStatementIsNotSupported struct{}
@@ -147,12 +337,42 @@ func (e *ErrStatementIsNotSupported) Error() string {
 	return "the statement isn't supported"
 }
 
+// ErrInvalidRole is an error returned when a GRANT or REVOKE role
+// is not a valid 20-byte hex eth address.
+type ErrInvalidRole struct {
+	Role string
+}
+
+func (e *ErrInvalidRole) Error() string {
+	return fmt.Sprintf("role '%s' is not a valid eth address", e.Role)
+}
+
 // ErrRoleIsNotAnEthAddress is an error returned when the role
 // is not an eth address.
-type ErrRoleIsNotAnEthAddress struct{}
+//
+// Deprecated: Use ErrInvalidRole instead.
+type ErrRoleIsNotAnEthAddress = ErrInvalidRole
+
+// ErrInvalidPolicyClause is an error returned when a policy clause isn't a valid
+// WHERE expression over the columns of the target table.
+type ErrInvalidPolicyClause struct {
+	Clause string
+	Reason string
+}
+
+func (e *ErrInvalidPolicyClause) Error() string {
+	return fmt.Sprintf("policy clause '%s' is invalid: %s", e.Clause, e.Reason)
+}
+
+// ErrInvalidReadFilter is an error returned when a filter to be injected in
+// a read query isn't a valid boolean expression.
+type ErrInvalidReadFilter struct {
+	Filter string
+	Reason string
+}
 
-func (e *ErrRoleIsNotAnEthAddress) Error() string {
-	return "role is not an eth address"
+func (e *ErrInvalidReadFilter) Error() string {
+	return fmt.Sprintf("read filter '%s' is invalid: %s", e.Filter, e.Reason)
 }
 
 // ErrNoTopLevelCreate is an error returned when a query isn't a CREATE.
@@ -162,6 +382,13 @@ func (e *ErrNoTopLevelCreate) Error() string {
 	return "the query isn't a CREATE"
 }
 
+// ErrNoTopLevelGrant is an error returned when a query isn't a single GRANT or REVOKE.
+type ErrNoTopLevelGrant struct{}
+
+func (e *ErrNoTopLevelGrant) Error() string {
+	return "the query isn't a single GRANT or REVOKE"
+}
+
 // ErrInvalidTableName is an error returned when a query references a table
 // without the right format.
 type ErrInvalidTableName struct{}
@@ -213,10 +440,41 @@ func (e *ErrInsertWithSelectChainMistmatch) Error() string {
 		"insert with select chain mismatch (insert chain %d, select chain %d)", e.InsertChainID, e.SelectChainID)
 }
 
+// ErrMixedOperations is an error returned when a write query mixes different
+// kinds of write statements and that isn't allowed.
+type ErrMixedOperations struct {
+	Op1 string
+	Op2 string
+}
+
+func (e *ErrMixedOperations) Error() string {
+	return fmt.Sprintf("write statements of different kinds can't be mixed (%s, %s)", e.Op1, e.Op2)
+}
+
+// ErrNonDeterministicLimit is an error returned when a read query has a LIMIT
+// without an ORDER BY, and that isn't allowed.
+type ErrNonDeterministicLimit struct{}
+
+func (e *ErrNonDeterministicLimit) Error() string {
+	return "queries with a limit clause must have an order by clause"
+}
+
+// ErrTypeDisabled is an error returned when a CREATE TABLE statement has a column
+// of an accepted type that isn't enabled.
+type ErrTypeDisabled struct {
+	Type string
+}
+
+func (e *ErrTypeDisabled) Error() string {
+	return fmt.Sprintf("column type %s isn't enabled", e.Type)
+}
+
 // Config contains configuration parameters for tableland.
 type Config struct {
 	MaxReadQuerySize  int
 	MaxWriteQuerySize int
+
+	RequireOrderByWithLimit bool
 }
 
 // DefaultConfig returns the default configuration.
@@ -251,3 +509,147 @@ func WithMaxWriteQuerySize(size int) Option {
 		return nil
 	}
 }
+
+// WithRequireOrderByWithLimit indicates if read queries with a LIMIT must have an ORDER BY.
+// Without an ORDER BY, the rows returned by a LIMIT can differ between validators. A LIMIT
+// on the rows of an ordered subquery, like the ones paginated reads add, is allowed.
+func WithRequireOrderByWithLimit(required bool) Option {
+	return func(c *Config) error {
+		c.RequireOrderByWithLimit = required
+		return nil
+	}
+}
+
+// GatewayConfig contains constraints that only apply to queries received by the gateway.
+// Executors must accept every query that's valid on-chain, so these constraints can only be
+// set on a gateway parser.
+type GatewayConfig struct {
+	AllowMixedOps                 bool
+	OrderInsensitiveStructureHash bool
+	CheckInsertColumnCount        bool
+	DisallowNullLiterals          bool
+	RequireDeleteWhere            bool
+	RequireUpdateWhere            bool
+	DeniedOperators               []string
+	// EnabledTypes are the accepted column types allowed in CREATE TABLE statements.
+	// If it's empty, all accepted types are allowed.
+	EnabledTypes []string
+	// CaseInsensitiveIdentifiers compares table names and system table prefixes ignoring casing,
+	// the same way SQLite resolves them.
+	CaseInsensitiveIdentifiers bool
+}
+
+// DefaultGatewayConfig returns the default gateway configuration, which accepts the same
+// queries as a parser that executes chain events.
+func DefaultGatewayConfig() *GatewayConfig {
+	return &GatewayConfig{
+		AllowMixedOps: true,
+	}
+}
+
+// GatewayOption modifies a gateway configuration attribute.
+type GatewayOption func(*GatewayConfig) error
+
+// WithAllowMixedOps indicates if a write query can mix INSERT, UPDATE and DELETE statements.
+// If not allowed, all the write statements of a query must be of the same kind.
+func WithAllowMixedOps(allow bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.AllowMixedOps = allow
+		return nil
+	}
+}
+
+// WithOrderInsensitiveStructureHash indicates if the structure hash of a CREATE TABLE
+// statement should ignore the order in which columns are declared.
+// By default, the hash depends on the column order.
+func WithOrderInsensitiveStructureHash(enabled bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.OrderInsensitiveStructureHash = enabled
+		return nil
+	}
+}
+
+// WithDisallowNullLiterals indicates if INSERT values and UPDATE set expressions are rejected
+// when they contain an explicit NULL. This is independent of NOT NULL column constraints.
+func WithDisallowNullLiterals(disallow bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.DisallowNullLiterals = disallow
+		return nil
+	}
+}
+
+// WithRequireDeleteWhere indicates if DELETE statements must have a WHERE clause, so a
+// query can't delete all the rows of a table by mistake.
+func WithRequireDeleteWhere(required bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.RequireDeleteWhere = required
+		return nil
+	}
+}
+
+// WithRequireUpdateWhere indicates if UPDATE statements must have a WHERE clause, so a
+// query can't rewrite all the rows of a table by mistake. It's independent of
+// WithRequireDeleteWhere.
+func WithRequireUpdateWhere(required bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.RequireUpdateWhere = required
+		return nil
+	}
+}
+
+// WithDeniedOperators rejects read queries using any of the provided operators, and write
+// queries using them in a WHERE clause. Operators are matched case-insensitively, and denying
+// an operator also denies its negated form (e.g: "regexp" denies "not regexp").
+// This is useful for expensive operators such as regexp or glob.
+func WithDeniedOperators(ops ...string) GatewayOption {
+	return func(c *GatewayConfig) error {
+		for _, op := range ops {
+			op = strings.ToLower(strings.TrimSpace(op))
+			if op == "" {
+				return fmt.Errorf("operator is empty")
+			}
+			c.DeniedOperators = append(c.DeniedOperators, op)
+		}
+		return nil
+	}
+}
+
+// WithEnabledTypes restricts the column types allowed in CREATE TABLE statements to the provided
+// accepted types. Columns of other accepted types are rejected with ErrTypeDisabled.
+// By default, all accepted types are enabled.
+func WithEnabledTypes(types ...string) GatewayOption {
+	return func(c *GatewayConfig) error {
+		accepted := map[string]bool{}
+		for _, name := range GetAcceptedTypeNames() {
+			accepted[name] = true
+		}
+		for _, typ := range types {
+			typ = strings.ToLower(strings.TrimSpace(typ))
+			if !accepted[typ] {
+				return fmt.Errorf("%q isn't an accepted type", typ)
+			}
+			c.EnabledTypes = append(c.EnabledTypes, typ)
+		}
+		return nil
+	}
+}
+
+// WithInsertColumnCountCheck indicates if INSERT statements with a column list are checked at
+// parse time to have the same number of values in every row.
+func WithInsertColumnCountCheck(enabled bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.CheckInsertColumnCount = enabled
+		return nil
+	}
+}
+
+// WithCaseInsensitiveIdentifiers indicates if table names are compared ignoring casing, so
+// "create table Foo_1" and "create table foo_1" are validated the same way. In particular,
+// system table prefixes are rejected with any casing, and a batch can reference its table
+// with different casings. Identifiers keep their original casing in the validated statements.
+func WithCaseInsensitiveIdentifiers(enabled bool) GatewayOption {
+	return func(c *GatewayConfig) error {
+		c.CaseInsensitiveIdentifiers = enabled
+		return nil
+	}
+}
@@ -32,3 +32,40 @@ func (q *Queries) GetAclByTableAndController(ctx context.Context, arg GetAclByTa
 	)
 	return i, err
 }
+
+const getCreateAclByController = `-- name: GetCreateAclByController :one
+SELECT chain_id, controller, privileges, created_at FROM system_create_acl WHERE chain_id = ?1 AND upper(controller) LIKE upper(?2)
+`
+
+type GetCreateAclByControllerParams struct {
+	ChainID int64
+	UPPER   string
+}
+
+func (q *Queries) GetCreateAclByController(ctx context.Context, arg GetCreateAclByControllerParams) (SystemCreateAcl, error) {
+	row := q.queryRow(ctx, q.getCreateAclByControllerStmt, getCreateAclByController, arg.ChainID, arg.UPPER)
+	var i SystemCreateAcl
+	err := row.Scan(
+		&i.ChainID,
+		&i.Controller,
+		&i.Privileges,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const grantCreateAcl = `-- name: GrantCreateAcl :exec
+INSERT INTO system_create_acl (chain_id, controller, privileges) VALUES (?1, ?2, ?3)
+ON CONFLICT (chain_id, controller) DO UPDATE SET privileges = privileges | excluded.privileges
+`
+
+type GrantCreateAclParams struct {
+	ChainID    int64
+	Controller string
+	Privileges int
+}
+
+func (q *Queries) GrantCreateAcl(ctx context.Context, arg GrantCreateAclParams) error {
+	_, err := q.exec(ctx, q.grantCreateAclStmt, grantCreateAcl, arg.ChainID, arg.Controller, arg.Privileges)
+	return err
+}
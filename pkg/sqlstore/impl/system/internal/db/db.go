@@ -33,6 +33,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAclByTableAndControllerStmt, err = db.PrepareContext(ctx, getAclByTableAndController); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAclByTableAndController: %w", err)
 	}
+	if q.getAuditLogStmt, err = db.PrepareContext(ctx, getAuditLog); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAuditLog: %w", err)
+	}
 	if q.getBlockExtraInfoStmt, err = db.PrepareContext(ctx, getBlockExtraInfo); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBlockExtraInfo: %w", err)
 	}
@@ -42,6 +45,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getBlocksMissingExtraInfoByBlockNumberStmt, err = db.PrepareContext(ctx, getBlocksMissingExtraInfoByBlockNumber); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBlocksMissingExtraInfoByBlockNumber: %w", err)
 	}
+	if q.getCreateAclByControllerStmt, err = db.PrepareContext(ctx, getCreateAclByController); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCreateAclByController: %w", err)
+	}
 	if q.getEVMEventsStmt, err = db.PrepareContext(ctx, getEVMEvents); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEVMEvents: %w", err)
 	}
@@ -51,6 +57,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getReceiptStmt, err = db.PrepareContext(ctx, getReceipt); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReceipt: %w", err)
 	}
+	if q.getReceiptsStmt, err = db.PrepareContext(ctx, getReceipts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReceipts: %w", err)
+	}
 	if q.getSchemaByTableNameStmt, err = db.PrepareContext(ctx, getSchemaByTableName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSchemaByTableName: %w", err)
 	}
@@ -63,6 +72,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getTablesByStructureStmt, err = db.PrepareContext(ctx, getTablesByStructure); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTablesByStructure: %w", err)
 	}
+	if q.grantCreateAclStmt, err = db.PrepareContext(ctx, grantCreateAcl); err != nil {
+		return nil, fmt.Errorf("error preparing query GrantCreateAcl: %w", err)
+	}
 	if q.insertBlockExtraInfoStmt, err = db.PrepareContext(ctx, insertBlockExtraInfo); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertBlockExtraInfo: %w", err)
 	}
@@ -101,6 +113,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAclByTableAndControllerStmt: %w", cerr)
 		}
 	}
+	if q.getAuditLogStmt != nil {
+		if cerr := q.getAuditLogStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAuditLogStmt: %w", cerr)
+		}
+	}
 	if q.getBlockExtraInfoStmt != nil {
 		if cerr := q.getBlockExtraInfoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getBlockExtraInfoStmt: %w", cerr)
@@ -116,6 +133,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getBlocksMissingExtraInfoByBlockNumberStmt: %w", cerr)
 		}
 	}
+	if q.getCreateAclByControllerStmt != nil {
+		if cerr := q.getCreateAclByControllerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCreateAclByControllerStmt: %w", cerr)
+		}
+	}
 	if q.getEVMEventsStmt != nil {
 		if cerr := q.getEVMEventsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEVMEventsStmt: %w", cerr)
@@ -131,6 +153,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getReceiptStmt: %w", cerr)
 		}
 	}
+	if q.getReceiptsStmt != nil {
+		if cerr := q.getReceiptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReceiptsStmt: %w", cerr)
+		}
+	}
 	if q.getSchemaByTableNameStmt != nil {
 		if cerr := q.getSchemaByTableNameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSchemaByTableNameStmt: %w", cerr)
@@ -151,6 +178,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getTablesByStructureStmt: %w", cerr)
 		}
 	}
+	if q.grantCreateAclStmt != nil {
+		if cerr := q.grantCreateAclStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing grantCreateAclStmt: %w", cerr)
+		}
+	}
 	if q.insertBlockExtraInfoStmt != nil {
 		if cerr := q.insertBlockExtraInfoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertBlockExtraInfoStmt: %w", cerr)
@@ -223,16 +255,20 @@ type Queries struct {
 	areEVMEventsPersistedStmt                  *sql.Stmt
 	deletePendingTxByHashStmt                  *sql.Stmt
 	getAclByTableAndControllerStmt             *sql.Stmt
+	getAuditLogStmt                            *sql.Stmt
 	getBlockExtraInfoStmt                      *sql.Stmt
 	getBlocksMissingExtraInfoStmt              *sql.Stmt
 	getBlocksMissingExtraInfoByBlockNumberStmt *sql.Stmt
+	getCreateAclByControllerStmt               *sql.Stmt
 	getEVMEventsStmt                           *sql.Stmt
 	getIdStmt                                  *sql.Stmt
 	getReceiptStmt                             *sql.Stmt
+	getReceiptsStmt                            *sql.Stmt
 	getSchemaByTableNameStmt                   *sql.Stmt
 	getTableStmt                               *sql.Stmt
 	getTablesByControllerStmt                  *sql.Stmt
 	getTablesByStructureStmt                   *sql.Stmt
+	grantCreateAclStmt                         *sql.Stmt
 	insertBlockExtraInfoStmt                   *sql.Stmt
 	insertEVMEventStmt                         *sql.Stmt
 	insertIdStmt                               *sql.Stmt
@@ -248,21 +284,25 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		areEVMEventsPersistedStmt:      q.areEVMEventsPersistedStmt,
 		deletePendingTxByHashStmt:      q.deletePendingTxByHashStmt,
 		getAclByTableAndControllerStmt: q.getAclByTableAndControllerStmt,
+		getAuditLogStmt:                q.getAuditLogStmt,
 		getBlockExtraInfoStmt:          q.getBlockExtraInfoStmt,
 		getBlocksMissingExtraInfoStmt:  q.getBlocksMissingExtraInfoStmt,
 		getBlocksMissingExtraInfoByBlockNumberStmt: q.getBlocksMissingExtraInfoByBlockNumberStmt,
-		getEVMEventsStmt:           q.getEVMEventsStmt,
-		getIdStmt:                  q.getIdStmt,
-		getReceiptStmt:             q.getReceiptStmt,
-		getSchemaByTableNameStmt:   q.getSchemaByTableNameStmt,
-		getTableStmt:               q.getTableStmt,
-		getTablesByControllerStmt:  q.getTablesByControllerStmt,
-		getTablesByStructureStmt:   q.getTablesByStructureStmt,
-		insertBlockExtraInfoStmt:   q.insertBlockExtraInfoStmt,
-		insertEVMEventStmt:         q.insertEVMEventStmt,
-		insertIdStmt:               q.insertIdStmt,
-		insertPendingTxStmt:        q.insertPendingTxStmt,
-		listPendingTxStmt:          q.listPendingTxStmt,
-		replacePendingTxByHashStmt: q.replacePendingTxByHashStmt,
+		getCreateAclByControllerStmt:               q.getCreateAclByControllerStmt,
+		getEVMEventsStmt:                           q.getEVMEventsStmt,
+		getIdStmt:                                  q.getIdStmt,
+		getReceiptStmt:                             q.getReceiptStmt,
+		getReceiptsStmt:                            q.getReceiptsStmt,
+		getSchemaByTableNameStmt:                   q.getSchemaByTableNameStmt,
+		getTableStmt:                               q.getTableStmt,
+		getTablesByControllerStmt:                  q.getTablesByControllerStmt,
+		getTablesByStructureStmt:                   q.getTablesByStructureStmt,
+		grantCreateAclStmt:                         q.grantCreateAclStmt,
+		insertBlockExtraInfoStmt:                   q.insertBlockExtraInfoStmt,
+		insertEVMEventStmt:                         q.insertEVMEventStmt,
+		insertIdStmt:                               q.insertIdStmt,
+		insertPendingTxStmt:                        q.insertPendingTxStmt,
+		listPendingTxStmt:                          q.listPendingTxStmt,
+		replacePendingTxByHashStmt:                 q.replacePendingTxByHashStmt,
 	}
 }
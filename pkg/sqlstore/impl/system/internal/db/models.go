@@ -31,12 +31,31 @@ type SystemAcl struct {
 	UpdatedAt  sql.NullInt64
 }
 
+type SystemAudit struct {
+	ID           int64
+	ChainID      int64
+	TableID      int64
+	Controller   string
+	Operation    string
+	RowsAffected int64
+	BlockNumber  int64
+	TxnHash      string
+	CreatedAt    int64
+}
+
 type SystemController struct {
 	ChainID    int64
 	TableID    int64
 	Controller string
 }
 
+type SystemCreateAcl struct {
+	ChainID    int64
+	Controller string
+	Privileges int
+	CreatedAt  int64
+}
+
 type SystemEvmBlock struct {
 	ChainID     int64
 	BlockNumber int64
@@ -71,6 +90,12 @@ type SystemPendingTx struct {
 	UpdatedAt      sql.NullInt64
 }
 
+type SystemTableDescription struct {
+	ChainID     int64
+	TableID     int64
+	Description string
+}
+
 type SystemTxnProcessor struct {
 	ChainID     int64
 	BlockNumber int64
@@ -84,4 +109,5 @@ type SystemTxnReceipt struct {
 	Error         sql.NullString
 	TableID       sql.NullInt64
 	ErrorEventIdx sql.NullInt64
+	ErrorCode     sql.NullString
 }
@@ -10,7 +10,7 @@ import (
 )
 
 const getReceipt = `-- name: GetReceipt :one
-SELECT chain_id, block_number, index_in_block, txn_hash, error, table_id, error_event_idx from system_txn_receipts WHERE chain_id=?1 and txn_hash=?2
+SELECT chain_id, block_number, index_in_block, txn_hash, error, table_id, error_event_idx, error_code from system_txn_receipts WHERE chain_id=?1 and txn_hash=?2
 `
 
 type GetReceiptParams struct {
@@ -29,6 +29,48 @@ func (q *Queries) GetReceipt(ctx context.Context, arg GetReceiptParams) (SystemT
 		&i.Error,
 		&i.TableID,
 		&i.ErrorEventIdx,
+		&i.ErrorCode,
 	)
 	return i, err
 }
+
+const getReceipts = `-- name: GetReceipts :many
+SELECT chain_id, block_number, index_in_block, txn_hash, error, table_id, error_event_idx, error_code from system_txn_receipts WHERE chain_id=?1 and txn_hash IN (SELECT value FROM json_each(?2))
+`
+
+type GetReceiptsParams struct {
+	ChainID   int64
+	TxnHashes string
+}
+
+func (q *Queries) GetReceipts(ctx context.Context, arg GetReceiptsParams) ([]SystemTxnReceipt, error) {
+	rows, err := q.query(ctx, q.getReceiptsStmt, getReceipts, arg.ChainID, arg.TxnHashes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SystemTxnReceipt
+	for rows.Next() {
+		var i SystemTxnReceipt
+		if err := rows.Scan(
+			&i.ChainID,
+			&i.BlockNumber,
+			&i.IndexInBlock,
+			&i.TxnHash,
+			&i.Error,
+			&i.TableID,
+			&i.ErrorEventIdx,
+			&i.ErrorCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
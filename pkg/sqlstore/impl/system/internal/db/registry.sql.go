@@ -7,10 +7,13 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const getTable = `-- name: GetTable :one
-SELECT id, structure, controller, prefix, created_at, chain_id FROM registry WHERE chain_id =?1 AND id = ?2
+SELECT registry.id, registry.structure, registry.controller, registry.prefix, registry.created_at, registry.chain_id, system_table_descriptions.description FROM registry
+LEFT JOIN system_table_descriptions ON system_table_descriptions.chain_id = registry.chain_id AND system_table_descriptions.table_id = registry.id
+WHERE registry.chain_id =?1 AND registry.id = ?2
 `
 
 type GetTableParams struct {
@@ -18,9 +21,19 @@ type GetTableParams struct {
 	ID      int64
 }
 
-func (q *Queries) GetTable(ctx context.Context, arg GetTableParams) (Registry, error) {
+type GetTableRow struct {
+	ID          int64
+	Structure   string
+	Controller  string
+	Prefix      string
+	CreatedAt   int64
+	ChainID     int64
+	Description sql.NullString
+}
+
+func (q *Queries) GetTable(ctx context.Context, arg GetTableParams) (GetTableRow, error) {
 	row := q.queryRow(ctx, q.getTableStmt, getTable, arg.ChainID, arg.ID)
-	var i Registry
+	var i GetTableRow
 	err := row.Scan(
 		&i.ID,
 		&i.Structure,
@@ -28,12 +41,15 @@ func (q *Queries) GetTable(ctx context.Context, arg GetTableParams) (Registry, e
 		&i.Prefix,
 		&i.CreatedAt,
 		&i.ChainID,
+		&i.Description,
 	)
 	return i, err
 }
 
 const getTablesByController = `-- name: GetTablesByController :many
-SELECT id, structure, controller, prefix, created_at, chain_id FROM registry WHERE chain_id=?1 AND upper(controller) LIKE upper(?2)
+SELECT registry.id, registry.structure, registry.controller, registry.prefix, registry.created_at, registry.chain_id, system_table_descriptions.description FROM registry
+LEFT JOIN system_table_descriptions ON system_table_descriptions.chain_id = registry.chain_id AND system_table_descriptions.table_id = registry.id
+WHERE registry.chain_id=?1 AND upper(registry.controller) LIKE upper(?2) ORDER BY registry.created_at, registry.id
 `
 
 type GetTablesByControllerParams struct {
@@ -41,15 +57,25 @@ type GetTablesByControllerParams struct {
 	UPPER   string
 }
 
-func (q *Queries) GetTablesByController(ctx context.Context, arg GetTablesByControllerParams) ([]Registry, error) {
+type GetTablesByControllerRow struct {
+	ID          int64
+	Structure   string
+	Controller  string
+	Prefix      string
+	CreatedAt   int64
+	ChainID     int64
+	Description sql.NullString
+}
+
+func (q *Queries) GetTablesByController(ctx context.Context, arg GetTablesByControllerParams) ([]GetTablesByControllerRow, error) {
 	rows, err := q.query(ctx, q.getTablesByControllerStmt, getTablesByController, arg.ChainID, arg.UPPER)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Registry
+	var items []GetTablesByControllerRow
 	for rows.Next() {
-		var i Registry
+		var i GetTablesByControllerRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Structure,
@@ -57,6 +83,7 @@ func (q *Queries) GetTablesByController(ctx context.Context, arg GetTablesByCont
 			&i.Prefix,
 			&i.CreatedAt,
 			&i.ChainID,
+			&i.Description,
 		); err != nil {
 			return nil, err
 		}
@@ -72,7 +99,9 @@ func (q *Queries) GetTablesByController(ctx context.Context, arg GetTablesByCont
 }
 
 const getTablesByStructure = `-- name: GetTablesByStructure :many
-SELECT id, structure, controller, prefix, created_at, chain_id FROM registry WHERE chain_id=?1 AND structure=?2
+SELECT registry.id, registry.structure, registry.controller, registry.prefix, registry.created_at, registry.chain_id, system_table_descriptions.description FROM registry
+LEFT JOIN system_table_descriptions ON system_table_descriptions.chain_id = registry.chain_id AND system_table_descriptions.table_id = registry.id
+WHERE registry.chain_id=?1 AND registry.structure=?2
 `
 
 type GetTablesByStructureParams struct {
@@ -80,15 +109,25 @@ type GetTablesByStructureParams struct {
 	Structure string
 }
 
-func (q *Queries) GetTablesByStructure(ctx context.Context, arg GetTablesByStructureParams) ([]Registry, error) {
+type GetTablesByStructureRow struct {
+	ID          int64
+	Structure   string
+	Controller  string
+	Prefix      string
+	CreatedAt   int64
+	ChainID     int64
+	Description sql.NullString
+}
+
+func (q *Queries) GetTablesByStructure(ctx context.Context, arg GetTablesByStructureParams) ([]GetTablesByStructureRow, error) {
 	rows, err := q.query(ctx, q.getTablesByStructureStmt, getTablesByStructure, arg.ChainID, arg.Structure)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Registry
+	var items []GetTablesByStructureRow
 	for rows.Next() {
-		var i Registry
+		var i GetTablesByStructureRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Structure,
@@ -96,6 +135,7 @@ func (q *Queries) GetTablesByStructure(ctx context.Context, arg GetTablesByStruc
 			&i.Prefix,
 			&i.CreatedAt,
 			&i.ChainID,
+			&i.Description,
 		); err != nil {
 			return nil, err
 		}
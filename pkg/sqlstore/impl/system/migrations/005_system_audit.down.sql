@@ -0,0 +1 @@
+DROP TABLE system_audit;
\ No newline at end of file
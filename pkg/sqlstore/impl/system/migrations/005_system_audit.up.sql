@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS system_audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    table_id INTEGER NOT NULL,
+    controller TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    rows_affected INTEGER NOT NULL,
+    block_number INTEGER NOT NULL,
+    txn_hash TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS system_audit_chain_id_table_id_idx ON system_audit (chain_id, table_id);
@@ -0,0 +1 @@
+DROP TABLE system_table_descriptions;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS system_table_descriptions (
+    chain_id INTEGER NOT NULL,
+    table_id INTEGER NOT NULL,
+    description TEXT NOT NULL,
+
+    PRIMARY KEY(chain_id, table_id)
+);
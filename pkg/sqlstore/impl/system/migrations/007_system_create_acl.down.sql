@@ -0,0 +1 @@
+DROP TABLE system_create_acl;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS system_create_acl (
+    chain_id INTEGER NOT NULL,
+    controller TEXT NOT NULL,
+    privileges INT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+
+    PRIMARY KEY(chain_id, controller)
+);
\ No newline at end of file
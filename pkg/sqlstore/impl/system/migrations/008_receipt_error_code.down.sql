@@ -0,0 +1 @@
+ALTER TABLE system_txn_receipts DROP COLUMN error_code;
\ No newline at end of file
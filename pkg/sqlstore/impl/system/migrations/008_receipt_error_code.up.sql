@@ -0,0 +1 @@
+ALTER TABLE system_txn_receipts ADD error_code TEXT;
\ No newline at end of file
@@ -9,6 +9,14 @@
 // migrations/003_evm_events.up.sql
 // migrations/004_system_id.down.sql
 // migrations/004_system_id.up.sql
+// migrations/005_system_audit.down.sql
+// migrations/005_system_audit.up.sql
+// migrations/006_table_descriptions.down.sql
+// migrations/006_table_descriptions.up.sql
+// migrations/007_system_create_acl.down.sql
+// migrations/007_system_create_acl.up.sql
+// migrations/008_receipt_error_code.down.sql
+// migrations/008_receipt_error_code.up.sql
 package migrations
 
 import (
@@ -245,6 +253,166 @@ func _004_system_idUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __005_system_auditDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\xae\x2c\x2e\x49\xcd\x8d\x4f\x2c\x4d\xc9\x2c\xb1\x06\x00\x2d\x83\x63\x35\x18\x00\x00\x00")
+
+func _005_system_auditDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__005_system_auditDownSql,
+		"005_system_audit.down.sql",
+	)
+}
+
+func _005_system_auditDownSql() (*asset, error) {
+	bytes, err := _005_system_auditDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "005_system_audit.down.sql", size: 24, mode: os.FileMode(420), modTime: time.Unix(1792153404, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __005_system_auditUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x90\x41\x8b\xc2\x30\x10\x85\xef\xfd\x15\x73\x54\xf0\x1f\xec\xa9\x5b\x47\x09\xd6\x54\xe2\x08\xf5\x14\xd2\x18\x69\xd9\x9a\x48\x1a\x51\xff\xbd\x51\xa9\x2c\xae\xdd\x61\x6e\xef\xcd\x7b\xc3\x97\x09\x4c\x09\x81\xd2\xef\x1c\x81\xcd\x80\x17\x04\x58\xb2\x35\xad\xa1\xbb\x76\xc1\x1c\xa4\x3a\xed\x9a\x00\xa3\x04\xe2\x34\x3b\x60\x9c\x70\x8e\x02\x56\x82\x2d\x53\xb1\x85\x05\x6e\x21\xdd\x50\xc1\x78\x26\x70\x89\x9c\x26\x0f\xa7\xae\x55\x63\xe5\x2f\xff\x3d\x98\x6f\xf2\xfc\x29\x07\x55\xb5\x66\x58\xd6\xce\x06\xef\xda\xd6\x78\x20\x2c\xe9\x4d\x75\x47\xe3\x55\x68\x9c\xfd\x24\x7a\x77\xee\xa4\xda\xef\x8d\x0e\x66\x28\xbe\x6a\x9d\xfe\x91\xf6\x74\xa8\x62\xc1\xc0\x83\x17\x2b\x6b\xd5\xd5\x9f\x2a\xb4\x37\x2a\x86\x4b\x15\xfe\x1c\x27\xe3\xaf\x24\xc9\x9e\x4c\x19\x9f\x62\xf9\x0f\x53\xd9\x33\x92\x3d\x8d\xb8\x17\x28\xf8\x1b\xf9\xde\x36\x79\x51\x8b\x25\x37\xfe\x0d\x5c\xd2\xb8\x01\x00\x00")
+
+func _005_system_auditUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__005_system_auditUpSql,
+		"005_system_audit.up.sql",
+	)
+}
+
+func _005_system_auditUpSql() (*asset, error) {
+	bytes, err := _005_system_auditUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "005_system_audit.up.sql", size: 440, mode: os.FileMode(420), modTime: time.Unix(1792153404, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __006_table_descriptionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\xae\x2c\x2e\x49\xcd\x8d\x2f\x49\x4c\xca\x49\x8d\x4f\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\x2b\xb6\x06\x00\x45\x42\x63\x58\x25\x00\x00\x00")
+
+func _006_table_descriptionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__006_table_descriptionsDownSql,
+		"006_table_descriptions.down.sql",
+	)
+}
+
+func _006_table_descriptionsDownSql() (*asset, error) {
+	bytes, err := _006_table_descriptionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "006_table_descriptions.down.sql", size: 37, mode: os.FileMode(420), modTime: time.Unix(1792154827, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __006_table_descriptionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x0e\x72\x75\x0c\x71\x55\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xae\x2c\x2e\x49\xcd\x8d\x2f\x49\x4c\xca\x49\x8d\x4f\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\xcc\xcf\x2b\x56\xd0\xe0\x52\x00\x82\xe4\x8c\xc4\xcc\xbc\xf8\xcc\x14\x05\x4f\xbf\x10\x57\x77\xd7\x20\xb0\x5e\xbf\x50\x1f\x1f\x1d\xb0\x34\x44\x1f\x4e\x69\x24\x03\x15\x42\x5c\x23\x42\x90\xa4\xc1\xf2\x01\x41\x9e\xbe\x8e\x41\x91\x0a\xde\xae\x91\x1a\x30\x9b\x74\xe0\x86\x6a\x72\x69\x5a\x73\x01\x00\x15\xb3\x12\x01\xbc\x00\x00\x00")
+
+func _006_table_descriptionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__006_table_descriptionsUpSql,
+		"006_table_descriptions.up.sql",
+	)
+}
+
+func _006_table_descriptionsUpSql() (*asset, error) {
+	bytes, err := _006_table_descriptionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "006_table_descriptions.up.sql", size: 188, mode: os.FileMode(420), modTime: time.Unix(1792154827, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __007_system_create_aclDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\xae\x2c\x2e\x49\xcd\x8d\x4f\x2e\x4a\x4d\x2c\x49\x8d\x4f\x4c\xce\xb1\x06\x00\xf1\x06\x9e\xab\x1d\x00\x00\x00")
+
+func _007_system_create_aclDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__007_system_create_aclDownSql,
+		"007_system_create_acl.down.sql",
+	)
+}
+
+func _007_system_create_aclDownSql() (*asset, error) {
+	bytes, err := _007_system_create_aclDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "007_system_create_acl.down.sql", size: 29, mode: os.FileMode(420), modTime: time.Unix(1792243115, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __007_system_create_aclUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x65\x8e\xcb\x0a\xc2\x30\x14\x44\xf7\xfd\x8a\xbb\x91\xa4\x90\x3f\x70\x55\xf5\x56\x82\xb5\x4a\x9a\x42\xbb\x0a\xa1\x8d\x1a\xe8\x43\x92\xa0\xf8\xf7\x6a\x45\x90\x3a\xdb\xe1\x9c\x99\xb5\xc0\x44\x22\xc8\x64\x95\x21\xf0\x14\xf2\x83\x04\xac\x78\x21\x0b\xf0\x0f\x1f\x4c\xaf\x1a\x67\x74\x30\x4a\x37\x1d\xd0\x08\x5e\x69\x2e\xda\x0e\xca\xb6\xc0\x73\x89\x5b\x14\x13\x93\x97\x59\xc6\x3e\xf5\x38\x04\x37\x76\x9d\x71\x20\xb1\x92\xb3\xf6\xea\xec\xcd\x76\xe6\x6c\xfc\x1b\x9f\xa3\xd3\x54\xab\x74\xf8\x73\xc3\x06\xd3\xa4\xcc\x24\x50\x1f\xdc\x29\xd8\xde\x50\xb2\xf0\x84\x01\x19\xc6\x3b\x89\x63\x16\x4d\x86\xa3\xe0\xfb\x44\xd4\xb0\xc3\x9a\x7e\x7f\xb2\x9f\x4b\x71\x14\x2f\x9f\xc2\x6c\x00\x80\xf3\x00\x00\x00")
+
+func _007_system_create_aclUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__007_system_create_aclUpSql,
+		"007_system_create_acl.up.sql",
+	)
+}
+
+func _007_system_create_aclUpSql() (*asset, error) {
+	bytes, err := _007_system_create_aclUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "007_system_create_acl.up.sql", size: 243, mode: os.FileMode(420), modTime: time.Unix(1792243115, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __008_receipt_error_codeDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xae\x2c\x2e\x49\xcd\x8d\x2f\xa9\xc8\x8b\x2f\x4a\x4d\x4e\xcd\x2c\x28\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2d\x2a\xca\x2f\x8a\x4f\xce\x4f\x49\xb5\x06\x00\x95\x10\x9b\xdf\x37\x00\x00\x00")
+
+func _008_receipt_error_codeDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__008_receipt_error_codeDownSql,
+		"008_receipt_error_code.down.sql",
+	)
+}
+
+func _008_receipt_error_codeDownSql() (*asset, error) {
+	bytes, err := _008_receipt_error_codeDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "008_receipt_error_code.down.sql", size: 55, mode: os.FileMode(420), modTime: time.Unix(1792247531, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __008_receipt_error_codeUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xae\x2c\x2e\x49\xcd\x8d\x2f\xa9\xc8\x8b\x2f\x4a\x4d\x4e\xcd\x2c\x28\x29\x56\x70\x74\x71\x51\x48\x2d\x2a\xca\x2f\x8a\x4f\xce\x4f\x49\x55\x08\x71\x8d\x08\xb1\x06\x00\x80\x04\x80\xb1\x34\x00\x00\x00")
+
+func _008_receipt_error_codeUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__008_receipt_error_codeUpSql,
+		"008_receipt_error_code.up.sql",
+	)
+}
+
+func _008_receipt_error_codeUpSql() (*asset, error) {
+	bytes, err := _008_receipt_error_codeUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "008_receipt_error_code.up.sql", size: 52, mode: os.FileMode(420), modTime: time.Unix(1792247531, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -297,25 +465,35 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"001_init.down.sql":            _001_initDownSql,
-	"001_init.up.sql":              _001_initUpSql,
-	"002_receipterroridx.down.sql": _002_receipterroridxDownSql,
-	"002_receipterroridx.up.sql":   _002_receipterroridxUpSql,
-	"003_evm_events.down.sql":      _003_evm_eventsDownSql,
-	"003_evm_events.up.sql":        _003_evm_eventsUpSql,
-	"004_system_id.down.sql":       _004_system_idDownSql,
-	"004_system_id.up.sql":         _004_system_idUpSql,
+	"001_init.down.sql":               _001_initDownSql,
+	"001_init.up.sql":                 _001_initUpSql,
+	"002_receipterroridx.down.sql":    _002_receipterroridxDownSql,
+	"002_receipterroridx.up.sql":      _002_receipterroridxUpSql,
+	"003_evm_events.down.sql":         _003_evm_eventsDownSql,
+	"003_evm_events.up.sql":           _003_evm_eventsUpSql,
+	"004_system_id.down.sql":          _004_system_idDownSql,
+	"004_system_id.up.sql":            _004_system_idUpSql,
+	"005_system_audit.down.sql":       _005_system_auditDownSql,
+	"005_system_audit.up.sql":         _005_system_auditUpSql,
+	"006_table_descriptions.down.sql": _006_table_descriptionsDownSql,
+	"006_table_descriptions.up.sql":   _006_table_descriptionsUpSql,
+	"007_system_create_acl.down.sql":  _007_system_create_aclDownSql,
+	"007_system_create_acl.up.sql":    _007_system_create_aclUpSql,
+	"008_receipt_error_code.down.sql": _008_receipt_error_codeDownSql,
+	"008_receipt_error_code.up.sql":   _008_receipt_error_codeUpSql,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -348,14 +526,22 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"001_init.down.sql":            &bintree{_001_initDownSql, map[string]*bintree{}},
-	"001_init.up.sql":              &bintree{_001_initUpSql, map[string]*bintree{}},
-	"002_receipterroridx.down.sql": &bintree{_002_receipterroridxDownSql, map[string]*bintree{}},
-	"002_receipterroridx.up.sql":   &bintree{_002_receipterroridxUpSql, map[string]*bintree{}},
-	"003_evm_events.down.sql":      &bintree{_003_evm_eventsDownSql, map[string]*bintree{}},
-	"003_evm_events.up.sql":        &bintree{_003_evm_eventsUpSql, map[string]*bintree{}},
-	"004_system_id.down.sql":       &bintree{_004_system_idDownSql, map[string]*bintree{}},
-	"004_system_id.up.sql":         &bintree{_004_system_idUpSql, map[string]*bintree{}},
+	"001_init.down.sql":               &bintree{_001_initDownSql, map[string]*bintree{}},
+	"001_init.up.sql":                 &bintree{_001_initUpSql, map[string]*bintree{}},
+	"002_receipterroridx.down.sql":    &bintree{_002_receipterroridxDownSql, map[string]*bintree{}},
+	"002_receipterroridx.up.sql":      &bintree{_002_receipterroridxUpSql, map[string]*bintree{}},
+	"003_evm_events.down.sql":         &bintree{_003_evm_eventsDownSql, map[string]*bintree{}},
+	"003_evm_events.up.sql":           &bintree{_003_evm_eventsUpSql, map[string]*bintree{}},
+	"004_system_id.down.sql":          &bintree{_004_system_idDownSql, map[string]*bintree{}},
+	"004_system_id.up.sql":            &bintree{_004_system_idUpSql, map[string]*bintree{}},
+	"005_system_audit.down.sql":       &bintree{_005_system_auditDownSql, map[string]*bintree{}},
+	"005_system_audit.up.sql":         &bintree{_005_system_auditUpSql, map[string]*bintree{}},
+	"006_table_descriptions.down.sql": &bintree{_006_table_descriptionsDownSql, map[string]*bintree{}},
+	"006_table_descriptions.up.sql":   &bintree{_006_table_descriptionsUpSql, map[string]*bintree{}},
+	"007_system_create_acl.down.sql":  &bintree{_007_system_create_aclDownSql, map[string]*bintree{}},
+	"007_system_create_acl.up.sql":    &bintree{_007_system_create_aclUpSql, map[string]*bintree{}},
+	"008_receipt_error_code.down.sql": &bintree{_008_receipt_error_codeDownSql, map[string]*bintree{}},
+	"008_receipt_error_code.up.sql":   &bintree{_008_receipt_error_codeUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -1,2 +1,9 @@
 -- name: GetAclByTableAndController :one
-SELECT * FROM system_acl WHERE chain_id = ?1 AND table_id = ?2 AND upper(controller) LIKE upper(?3);
\ No newline at end of file
+SELECT * FROM system_acl WHERE chain_id = ?1 AND table_id = ?2 AND upper(controller) LIKE upper(?3);
+
+-- name: GetCreateAclByController :one
+SELECT * FROM system_create_acl WHERE chain_id = ?1 AND upper(controller) LIKE upper(?2);
+
+-- name: GrantCreateAcl :exec
+INSERT INTO system_create_acl (chain_id, controller, privileges) VALUES (?1, ?2, ?3)
+ON CONFLICT (chain_id, controller) DO UPDATE SET privileges = privileges | excluded.privileges;
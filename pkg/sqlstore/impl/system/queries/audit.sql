@@ -0,0 +1,2 @@
+-- name: GetAuditLog :many
+SELECT * FROM system_audit WHERE chain_id=?1 AND table_id=?2 AND id>?3 ORDER BY id LIMIT ?4;
@@ -1,2 +1,5 @@
 -- name: GetReceipt :one
-SELECT * from system_txn_receipts WHERE chain_id=?1 and txn_hash=?2;
\ No newline at end of file
+SELECT * from system_txn_receipts WHERE chain_id=?1 and txn_hash=?2;
+
+-- name: GetReceipts :many
+SELECT * from system_txn_receipts WHERE chain_id=sqlc.arg(chain_id) and txn_hash IN (SELECT value FROM json_each(sqlc.arg(txn_hashes)));
\ No newline at end of file
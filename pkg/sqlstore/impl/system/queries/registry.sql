@@ -1,8 +1,14 @@
 -- name: GetTable :one
-SELECT * FROM registry WHERE chain_id =?1 AND id = ?2;
+SELECT registry.*, system_table_descriptions.description FROM registry
+LEFT JOIN system_table_descriptions ON system_table_descriptions.chain_id = registry.chain_id AND system_table_descriptions.table_id = registry.id
+WHERE registry.chain_id =?1 AND registry.id = ?2;
 
 -- name: GetTablesByController :many
-SELECT * FROM registry WHERE chain_id=?1 AND upper(controller) LIKE upper(?2);
+SELECT registry.*, system_table_descriptions.description FROM registry
+LEFT JOIN system_table_descriptions ON system_table_descriptions.chain_id = registry.chain_id AND system_table_descriptions.table_id = registry.id
+WHERE registry.chain_id=?1 AND upper(registry.controller) LIKE upper(?2) ORDER BY registry.created_at, registry.id;
 
 -- name: GetTablesByStructure :many
-SELECT * FROM registry WHERE chain_id=?1 AND structure=?2;
\ No newline at end of file
+SELECT registry.*, system_table_descriptions.description FROM registry
+LEFT JOIN system_table_descriptions ON system_table_descriptions.chain_id = registry.chain_id AND system_table_descriptions.table_id = registry.id
+WHERE registry.chain_id=?1 AND registry.structure=?2;
\ No newline at end of file
@@ -0,0 +1,96 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/textileio/go-tableland/pkg/sqlstore/impl/system/migrations"
+)
+
+// readinessTables are the system tables that must exist before serving requests.
+var readinessTables = []string{"registry", "system_acl", "system_txn_receipts"}
+
+// ReadinessChecker checks if the database has the system tables migrated to the latest
+// schema version.
+type ReadinessChecker struct {
+	db            *sql.DB
+	schemaVersion uint
+}
+
+// NewReadinessChecker returns a new ReadinessChecker for the database at dbURI.
+// It doesn't run any migration.
+func NewReadinessChecker(dbURI string) (*ReadinessChecker, error) {
+	schemaVersion, err := latestMigrationVersion()
+	if err != nil {
+		return nil, fmt.Errorf("get latest migration version: %s", err)
+	}
+	db, err := sql.Open("sqlite3", dbURI)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to db: %s", err)
+	}
+
+	return &ReadinessChecker{
+		db:            db,
+		schemaVersion: schemaVersion,
+	}, nil
+}
+
+// CheckReadiness returns an error if any of the system tables doesn't exist, or the
+// migrations haven't run up to the latest version.
+func (c *ReadinessChecker) CheckReadiness(ctx context.Context) error {
+	for _, name := range readinessTables {
+		var exists bool
+		if err := c.db.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type='table' AND name=?1)", name).Scan(&exists); err != nil {
+			return fmt.Errorf("checking table %s: %s", name, err)
+		}
+		if !exists {
+			return fmt.Errorf("table %s doesn't exist", name)
+		}
+	}
+
+	var version uint
+	var dirty bool
+	if err := c.db.QueryRowContext(ctx,
+		"SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty); err != nil {
+		return fmt.Errorf("get schema version: %s", err)
+	}
+	if dirty {
+		return fmt.Errorf("migration %d didn't finish", version)
+	}
+	if version < c.schemaVersion {
+		return fmt.Errorf("schema version is %d but expected %d", version, c.schemaVersion)
+	}
+
+	return nil
+}
+
+// Close closes the database connection.
+func (c *ReadinessChecker) Close() error {
+	if err := c.db.Close(); err != nil {
+		return fmt.Errorf("closing db: %s", err)
+	}
+	return nil
+}
+
+// latestMigrationVersion returns the version of the last migration, e.g: 6 for 006_table_descriptions.up.sql.
+func latestMigrationVersion() (uint, error) {
+	var latest uint
+	for _, name := range migrations.AssetNames() {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return 0, fmt.Errorf("migration %s doesn't have a version", name)
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing version of migration %s: %s", name, err)
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,48 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/tests"
+)
+
+func TestReadinessChecker(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("not migrated", func(t *testing.T) {
+		t.Parallel()
+
+		dbURI := tests.Sqlite3URI(t)
+		db, err := sql.Open("sqlite3", dbURI)
+		require.NoError(t, err)
+		_, err = db.Exec("CREATE TABLE registry (id INTEGER)")
+		require.NoError(t, err)
+
+		checker, err := NewReadinessChecker(dbURI)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, checker.Close()) }()
+
+		err = checker.CheckReadiness(ctx)
+		require.ErrorContains(t, err, "system_acl")
+	})
+
+	t.Run("migrated", func(t *testing.T) {
+		t.Parallel()
+
+		dbURI := tests.Sqlite3URI(t)
+		store, err := New(dbURI, tableland.ChainID(1337))
+		require.NoError(t, err)
+		defer func() { require.NoError(t, store.Close()) }()
+
+		checker, err := NewReadinessChecker(dbURI)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, checker.Close()) }()
+
+		require.NoError(t, checker.CheckReadiness(ctx))
+	})
+}
@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -88,10 +89,10 @@ func (s *SystemStore) GetTable(ctx context.Context, id tables.TableID) (sqlstore
 	if err != nil {
 		return sqlstore.Table{}, fmt.Errorf("failed to get the table: %w", err)
 	}
-	return tableFromSQLToDTO(table)
+	return tableToDTO(table)
 }
 
-// GetTablesByController fetchs a table from controller address.
+// GetTablesByController fetchs the tables of a controller address ordered by creation time.
 func (s *SystemStore) GetTablesByController(ctx context.Context, controller string) ([]sqlstore.Table, error) {
 	if err := sanitizeAddress(controller); err != nil {
 		return []sqlstore.Table{}, fmt.Errorf("sanitizing address: %s", err)
@@ -106,7 +107,7 @@ func (s *SystemStore) GetTablesByController(ctx context.Context, controller stri
 
 	tables := make([]sqlstore.Table, len(sqlcTables))
 	for i := range sqlcTables {
-		tables[i], err = tableFromSQLToDTO(sqlcTables[i])
+		tables[i], err = tableToDTO(db.GetTableRow(sqlcTables[i]))
 		if err != nil {
 			return nil, fmt.Errorf("parsing database table to dto: %s", err)
 		}
@@ -142,6 +143,86 @@ func (s *SystemStore) GetACLOnTableByController(
 	return aclFromSQLtoDTO(systemACL)
 }
 
+// GetCreatePrivilegesByController returns the create privileges granted to a controller. Create
+// privileges aren't related to a table, so they're stored apart from the table privileges.
+func (s *SystemStore) GetCreatePrivilegesByController(
+	ctx context.Context,
+	controller string,
+) (tableland.Privileges, error) {
+	params := db.GetCreateAclByControllerParams{
+		ChainID: int64(s.chainID),
+		UPPER:   controller,
+	}
+
+	createACL, err := s.dbWithTx.queries().GetCreateAclByController(ctx, params)
+	if err == sql.ErrNoRows {
+		return tableland.Privileges{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the create acl info: %s", err)
+	}
+
+	privileges := tableland.Privileges{}
+	if createACL.Privileges&tableland.PrivCreate.Bitfield > 0 {
+		privileges = append(privileges, tableland.PrivCreate)
+	}
+	return privileges, nil
+}
+
+// GrantCreatePrivilege grants the create privilege to a controller.
+func (s *SystemStore) GrantCreatePrivilege(ctx context.Context, controller common.Address) error {
+	params := db.GrantCreateAclParams{
+		ChainID:    int64(s.chainID),
+		Controller: controller.Hex(),
+		Privileges: tableland.PrivCreate.Bitfield,
+	}
+	if err := s.dbWithTx.queries().GrantCreateAcl(ctx, params); err != nil {
+		return fmt.Errorf("granting create privilege: %s", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns a page of the audit log entries of a table, with ids greater than afterID.
+func (s *SystemStore) GetAuditLog(
+	ctx context.Context,
+	id tables.TableID,
+	afterID int64,
+	limit int,
+) ([]sqlstore.AuditEntry, error) {
+	params := db.GetAuditLogParams{
+		ChainID: int64(s.chainID),
+		TableID: id.ToBigInt().Int64(),
+		ID:      afterID,
+		Limit:   int64(limit),
+	}
+
+	res, err := s.dbWithTx.queries().GetAuditLog(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("get audit log: %s", err)
+	}
+
+	entries := make([]sqlstore.AuditEntry, len(res))
+	for i, r := range res {
+		tableID, err := tables.NewTableIDFromInt64(r.TableID)
+		if err != nil {
+			return nil, fmt.Errorf("parsing id to string: %s", err)
+		}
+		entries[i] = sqlstore.AuditEntry{
+			ID:           r.ID,
+			ChainID:      tableland.ChainID(r.ChainID),
+			TableID:      tableID,
+			Controller:   r.Controller,
+			Operation:    r.Operation,
+			RowsAffected: r.RowsAffected,
+			BlockNumber:  r.BlockNumber,
+			TxnHash:      r.TxnHash,
+			CreatedAt:    time.Unix(r.CreatedAt, 0),
+		}
+	}
+
+	return entries, nil
+}
+
 // ListPendingTx lists all pendings txs.
 func (s *SystemStore) ListPendingTx(ctx context.Context, addr common.Address) ([]nonce.PendingTx, error) {
 	params := db.ListPendingTxParams{
@@ -230,7 +311,7 @@ func (s *SystemStore) GetTablesByStructure(ctx context.Context, structure string
 
 	tables := make([]sqlstore.Table, len(rows))
 	for i := range rows {
-		tables[i], err = tableFromSQLToDTO(rows[i])
+		tables[i], err = tableToDTO(db.GetTableRow(rows[i]))
 		if err != nil {
 			return nil, fmt.Errorf("parsing database table to dto: %s", err)
 		}
@@ -264,13 +345,18 @@ func (s *SystemStore) GetSchemaByTableName(ctx context.Context, name string) (sq
 	columns := make([]sqlstore.ColumnSchema, len(createTableNode.ColumnsDef))
 	for i, col := range createTableNode.ColumnsDef {
 		colConstraints := []string{}
+		var notNull bool
 		for _, colConstraint := range col.Constraints {
 			colConstraints = append(colConstraints, colConstraint.String())
+			if _, ok := colConstraint.(*sqlparser.ColumnConstraintNotNull); ok {
+				notNull = true
+			}
 		}
 
 		columns[i] = sqlstore.ColumnSchema{
 			Name:        col.Column.String(),
 			Type:        strings.ToLower(col.Type),
+			NotNull:     notNull,
 			Constraints: colConstraints,
 		}
 	}
@@ -338,27 +424,43 @@ func (s *SystemStore) GetReceipt(
 		return eventprocessor.Receipt{}, false, fmt.Errorf("get receipt: %s", err)
 	}
 
-	receipt := eventprocessor.Receipt{
-		ChainID:      s.chainID,
-		BlockNumber:  res.BlockNumber,
-		IndexInBlock: res.IndexInBlock,
-		TxnHash:      txnHash,
+	receipt, err := receiptFromSQLToDTO(res)
+	if err != nil {
+		return eventprocessor.Receipt{}, false, fmt.Errorf("parsing receipt: %s", err)
 	}
-	if res.Error.Valid {
-		receipt.Error = &res.Error.String
 
-		errorEventIdx := int(res.ErrorEventIdx.Int64)
-		receipt.ErrorEventIdx = &errorEventIdx
+	return receipt, true, nil
+}
+
+// GetReceipts returns the event receipts for the provided transaction hashes.
+// Hashes without a receipt are not included in the result.
+func (s *SystemStore) GetReceipts(ctx context.Context, txnHashes []string) ([]eventprocessor.Receipt, error) {
+	if len(txnHashes) == 0 {
+		return []eventprocessor.Receipt{}, nil
 	}
-	if res.TableID.Valid {
-		id, err := tables.NewTableIDFromInt64(res.TableID.Int64)
+	hashes, err := json.Marshal(txnHashes)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling txn hashes: %s", err)
+	}
+	params := db.GetReceiptsParams{
+		ChainID:   int64(s.chainID),
+		TxnHashes: string(hashes),
+	}
+
+	res, err := s.dbWithTx.queries().GetReceipts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("get receipts: %s", err)
+	}
+
+	receipts := make([]eventprocessor.Receipt, len(res))
+	for i := range res {
+		receipts[i], err = receiptFromSQLToDTO(res[i])
 		if err != nil {
-			return eventprocessor.Receipt{}, false, fmt.Errorf("parsing id to string: %s", err)
+			return nil, fmt.Errorf("parsing receipt: %s", err)
 		}
-		receipt.TableID = &id
 	}
 
-	return receipt, true, nil
+	return receipts, nil
 }
 
 // AreEVMEventsPersisted returns true if there're events persisted for the provided txn hash, and false otherwise.
@@ -532,21 +634,47 @@ func (s *SystemStore) executeMigration(dbURI string, as *bindata.AssetSource) er
 	return nil
 }
 
-func tableFromSQLToDTO(table db.Registry) (sqlstore.Table, error) {
+// tableToDTO converts a registry entry joined with its description to a sqlstore.Table.
+func tableToDTO(table db.GetTableRow) (sqlstore.Table, error) {
 	id, err := tables.NewTableIDFromInt64(table.ID)
 	if err != nil {
 		return sqlstore.Table{}, fmt.Errorf("parsing id to string: %s", err)
 	}
 	return sqlstore.Table{
-		ID:         id,
-		ChainID:    tableland.ChainID(table.ChainID),
-		Controller: table.Controller,
-		Prefix:     table.Prefix,
-		Structure:  table.Structure,
-		CreatedAt:  time.Unix(table.CreatedAt, 0),
+		ID:          id,
+		ChainID:     tableland.ChainID(table.ChainID),
+		Controller:  table.Controller,
+		Prefix:      table.Prefix,
+		Structure:   table.Structure,
+		CreatedAt:   time.Unix(table.CreatedAt, 0),
+		Description: table.Description.String,
 	}, nil
 }
 
+func receiptFromSQLToDTO(res db.SystemTxnReceipt) (eventprocessor.Receipt, error) {
+	receipt := eventprocessor.Receipt{
+		ChainID:      tableland.ChainID(res.ChainID),
+		BlockNumber:  res.BlockNumber,
+		IndexInBlock: res.IndexInBlock,
+		TxnHash:      res.TxnHash,
+	}
+	if res.Error.Valid {
+		receipt.Error = &res.Error.String
+		receipt.ErrorCode = res.ErrorCode.String
+
+		errorEventIdx := int(res.ErrorEventIdx.Int64)
+		receipt.ErrorEventIdx = &errorEventIdx
+	}
+	if res.TableID.Valid {
+		id, err := tables.NewTableIDFromInt64(res.TableID.Int64)
+		if err != nil {
+			return eventprocessor.Receipt{}, fmt.Errorf("parsing id to string: %s", err)
+		}
+		receipt.TableID = &id
+	}
+	return receipt, nil
+}
+
 func aclFromSQLtoDTO(acl db.SystemAcl) (sqlstore.SystemACL, error) {
 	id, err := tables.NewTableIDFromInt64(acl.TableID)
 	if err != nil {
@@ -563,6 +691,9 @@ func aclFromSQLtoDTO(acl db.SystemAcl) (sqlstore.SystemACL, error) {
 	if acl.Privileges&tableland.PrivDelete.Bitfield > 0 {
 		privileges = append(privileges, tableland.PrivDelete)
 	}
+	if acl.Privileges&tableland.PrivCreate.Bitfield > 0 {
+		privileges = append(privileges, tableland.PrivCreate)
+	}
 
 	systemACL := sqlstore.SystemACL{
 		ChainID:    tableland.ChainID(acl.ChainID),
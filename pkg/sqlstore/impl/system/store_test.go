@@ -0,0 +1,107 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/tests"
+)
+
+func TestMigrationsAreIdempotent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dbURI := tests.Sqlite3URI(t)
+	for i := 0; i < 2; i++ {
+		store, err := New(dbURI, tableland.ChainID(1337))
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+	}
+
+	checker, err := NewReadinessChecker(dbURI)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, checker.Close()) }()
+	require.NoError(t, checker.CheckReadiness(ctx))
+
+	var version uint
+	var dirty bool
+	require.NoError(t, checker.db.QueryRowContext(ctx,
+		"SELECT version, dirty FROM schema_migrations").Scan(&version, &dirty))
+	require.Equal(t, checker.schemaVersion, version)
+	require.False(t, dirty)
+}
+
+func TestTableDescription(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dbURI := tests.Sqlite3URI(t)
+	store, err := New(dbURI, tableland.ChainID(1337))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	controller := "0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO registry (chain_id, id, structure, controller, prefix, created_at)
+		 VALUES (1337, 1, 'h', ?1, 'foo', 0), (1337, 2, 'h', ?1, 'bar', 1)`, controller)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO system_table_descriptions (chain_id, table_id, description) VALUES (1337, 1, 'my table')")
+	require.NoError(t, err)
+
+	id, err := tables.NewTableIDFromInt64(1)
+	require.NoError(t, err)
+	table, err := store.GetTable(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "my table", table.Description)
+
+	byController, err := store.GetTablesByController(ctx, controller)
+	require.NoError(t, err)
+	require.Len(t, byController, 2)
+	require.Equal(t, "my table", byController[0].Description)
+	require.Equal(t, "", byController[1].Description)
+
+	byStructure, err := store.GetTablesByStructure(ctx, "h")
+	require.NoError(t, err)
+	require.Len(t, byStructure, 2)
+}
+
+func TestCreatePrivileges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dbURI := tests.Sqlite3URI(t)
+	store, err := New(dbURI, tableland.ChainID(1337))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	creator := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	privileges, err := store.GetCreatePrivilegesByController(ctx, creator.Hex())
+	require.NoError(t, err)
+	require.Empty(t, privileges)
+
+	// Granting twice is a noop.
+	require.NoError(t, store.GrantCreatePrivilege(ctx, creator))
+	require.NoError(t, store.GrantCreatePrivilege(ctx, creator))
+
+	privileges, err = store.GetCreatePrivilegesByController(ctx, strings.ToLower(creator.Hex()))
+	require.NoError(t, err)
+	require.Equal(t, tableland.Privileges{tableland.PrivCreate}, privileges)
+
+	// Privileges are granted per chain.
+	other, err := New(dbURI, tableland.ChainID(1))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, other.Close()) }()
+	privileges, err = other.GetCreatePrivilegesByController(ctx, creator.Hex())
+	require.NoError(t, err)
+	require.Empty(t, privileges)
+}
@@ -156,6 +156,69 @@ func (s *InstrumentedSystemStore) GetACLOnTableByController(
 	return systemACL, err
 }
 
+// GetCreatePrivilegesByController implements sqlstore.SystemStore.
+func (s *InstrumentedSystemStore) GetCreatePrivilegesByController(
+	ctx context.Context,
+	controller string,
+) (tableland.Privileges, error) {
+	start := time.Now()
+	privileges, err := s.store.GetCreatePrivilegesByController(ctx, controller)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetCreatePrivilegesByController")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(s.chainID))},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return privileges, err
+}
+
+// GrantCreatePrivilege implements sqlstore.SystemStore.
+func (s *InstrumentedSystemStore) GrantCreatePrivilege(ctx context.Context, controller common.Address) error {
+	start := time.Now()
+	err := s.store.GrantCreatePrivilege(ctx, controller)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GrantCreatePrivilege")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(s.chainID))},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return err
+}
+
+// GetAuditLog implements sqlstore.SystemStore.
+func (s *InstrumentedSystemStore) GetAuditLog(
+	ctx context.Context,
+	id tables.TableID,
+	afterID int64,
+	limit int,
+) ([]sqlstore.AuditEntry, error) {
+	log.Debug().Str("id", id.String()).Int64("after_id", afterID).Int("limit", limit).Msg("call GetAuditLog")
+	start := time.Now()
+	entries, err := s.store.GetAuditLog(ctx, id, afterID, limit)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetAuditLog")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(s.chainID))},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return entries, err
+}
+
 // ListPendingTx lists all pendings txs.
 func (s *InstrumentedSystemStore) ListPendingTx(
 	ctx context.Context,
@@ -277,6 +340,28 @@ func (s *InstrumentedSystemStore) GetReceipt(
 	return receipt, ok, err
 }
 
+// GetReceipts returns the receipts of processed events by txn hashes.
+func (s *InstrumentedSystemStore) GetReceipts(
+	ctx context.Context,
+	txnHashes []string,
+) ([]eventprocessor.Receipt, error) {
+	log.Debug().Int("count", len(txnHashes)).Msg("call GetReceipts")
+	start := time.Now()
+	receipts, err := s.store.GetReceipts(ctx, txnHashes)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetReceipts")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(s.chainID))},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return receipts, err
+}
+
 // AreEVMEventsPersisted implements sqlstore.SystemStore.
 func (s *InstrumentedSystemStore) AreEVMEventsPersisted(ctx context.Context, txnHash common.Hash) (bool, error) {
 	log.Debug().Str("txn_hash", txnHash.Hex()).Msg("call AreEVMEventsPersisted")
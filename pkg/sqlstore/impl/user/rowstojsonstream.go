@@ -0,0 +1,95 @@
+package user
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/sqlstore"
+)
+
+// streamFlushRows is the number of rows written between flushes of the underlying writer.
+const streamFlushRows = 1000
+
+type flusher interface {
+	Flush()
+}
+
+// rowsToJSONStream writes rows to w as a JSON array of objects with the same shape as
+// the objects output of the formatter, keeping the keys in the same order as the columns.
+func rowsToJSONStream(rows *sql.Rows, w io.Writer, maxRows int) error {
+	columns, err := getColumnsData(rows)
+	if err != nil {
+		return fmt.Errorf("get columns from rows: %s", err)
+	}
+	keys := make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col.Name)
+		if err != nil {
+			return fmt.Errorf("marshaling column name: %s", err)
+		}
+		keys[i] = key
+	}
+
+	bw := bufio.NewWriter(w)
+	flush := func() error {
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("flushing writer: %s", err)
+		}
+		if f, ok := w.(flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	vals := make([]*tableland.ColumnValue, len(columns))
+	scanArgs := make([]interface{}, len(columns))
+	for i := range vals {
+		vals[i] = &tableland.ColumnValue{}
+		scanArgs[i] = vals[i]
+	}
+
+	_ = bw.WriteByte('[')
+	var count int
+	for rows.Next() {
+		if maxRows > 0 && count == maxRows {
+			return &sqlstore.ErrTooManyRows{Max: maxRows}
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return fmt.Errorf("scan row column: %s", err)
+		}
+		if count > 0 {
+			_ = bw.WriteByte(',')
+		}
+		_ = bw.WriteByte('{')
+		for i, val := range vals {
+			if i > 0 {
+				_ = bw.WriteByte(',')
+			}
+			b, err := json.Marshal(val)
+			if err != nil {
+				return fmt.Errorf("marshaling value: %s", err)
+			}
+			_, _ = bw.Write(keys[i])
+			_ = bw.WriteByte(':')
+			_, _ = bw.Write(b)
+		}
+		_ = bw.WriteByte('}')
+
+		count++
+		if count%streamFlushRows == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating rows: %s", err)
+	}
+	_ = bw.WriteByte(']')
+
+	return flush()
+}
@@ -3,16 +3,18 @@ package user
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/sqlstore"
 )
 
-func rowsToTableData(rows *sql.Rows) (*tableland.TableData, error) {
+func rowsToTableData(rows *sql.Rows, maxRows int) (*tableland.TableData, error) {
 	columns, err := getColumnsData(rows)
 	if err != nil {
 		return nil, fmt.Errorf("get columns from rows: %s", err)
 	}
-	rowsData, err := getRowsData(rows, len(columns))
+	rowsData, err := getRowsData(rows, len(columns), maxRows)
 	if err != nil {
 		return nil, err
 	}
@@ -35,9 +37,35 @@ func getColumnsData(rows *sql.Rows) ([]tableland.Column, error) {
 	return columns, nil
 }
 
-func getRowsData(rows *sql.Rows, numColumns int) ([][]*tableland.ColumnValue, error) {
+func rowsToReadResult(rows *sql.Rows, maxRows int) (sqlstore.ReadResult, error) {
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return sqlstore.ReadResult{}, fmt.Errorf("get column types from sql.Rows: %s", err)
+	}
+	columns := make([]sqlstore.ColumnDescriptor, len(colTypes))
+	for i, ct := range colTypes {
+		columns[i] = sqlstore.ColumnDescriptor{
+			Name: ct.Name(),
+			Type: strings.ToLower(ct.DatabaseTypeName()),
+		}
+	}
+	rowsData, err := getRowsData(rows, len(columns), maxRows)
+	if err != nil {
+		return sqlstore.ReadResult{}, err
+	}
+
+	return sqlstore.ReadResult{
+		Columns: columns,
+		Rows:    rowsData,
+	}, nil
+}
+
+func getRowsData(rows *sql.Rows, numColumns int, maxRows int) ([][]*tableland.ColumnValue, error) {
 	rowsData := make([][]*tableland.ColumnValue, 0)
 	for rows.Next() {
+		if maxRows > 0 && len(rowsData) == maxRows {
+			return nil, &sqlstore.ErrTooManyRows{Max: maxRows}
+		}
 		vals := make([]*tableland.ColumnValue, numColumns)
 		for i := range vals {
 			val := &tableland.ColumnValue{}
@@ -52,5 +80,9 @@ func getRowsData(rows *sql.Rows, numColumns int) ([][]*tableland.ColumnValue, er
 		}
 		rowsData = append(rowsData, vals)
 	}
+	// An interrupted query stops the iteration early, which is only reported here.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %s", err)
+	}
 	return rowsData, nil
 }
@@ -3,7 +3,12 @@ package user
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/XSAM/otelsql"
 	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
@@ -12,6 +17,7 @@ import (
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/pkg/metrics"
 	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/sqlstore"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -21,10 +27,109 @@ var log = logger.With().Str("component", "userstore").Logger()
 type UserStore struct {
 	db       *sql.DB
 	resolver sqlparser.ReadStatementResolver
+	maxRows  int
+	config   *Config
+}
+
+// Config contains configuration parameters for the user store.
+type Config struct {
+	// MaxQueryCost is the maximum estimated cost of a read query. Zero means no limit.
+	MaxQueryCost int64
+
+	// MaxOpenConns is the maximum number of open connections to the db. Zero means no limit.
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of idle connections kept in the pool.
+	MaxIdleConns int
+	// ConnMaxLifetime is the maximum amount of time a connection may be reused. Zero means forever.
+	ConnMaxLifetime time.Duration
+	// AcquireTimeout is the maximum amount of time a read waits for a connection of the pool.
+	// Zero means it waits until the context is done.
+	AcquireTimeout time.Duration
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxQueryCost:    0,
+		MaxOpenConns:    0,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 0,
+		AcquireTimeout:  0,
+	}
+}
+
+// Option modifies a configuration attribute.
+type Option func(*Config) error
+
+// WithMaxQueryCost rejects read queries with an estimated cost greater than max with
+// *sqlstore.ErrQueryTooCostly. The cost is roughly the number of rows the query scans.
+func WithMaxQueryCost(max int64) Option {
+	return func(c *Config) error {
+		if max < 0 {
+			return fmt.Errorf("maximum query cost is negative")
+		}
+		c.MaxQueryCost = max
+		return nil
+	}
+}
+
+// WithMaxOpenConns limits the number of open connections to the db.
+func WithMaxOpenConns(n int) Option {
+	return func(c *Config) error {
+		if n < 0 {
+			return fmt.Errorf("maximum open connections is negative")
+		}
+		c.MaxOpenConns = n
+		return nil
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept in the pool.
+func WithMaxIdleConns(n int) Option {
+	return func(c *Config) error {
+		if n < 0 {
+			return fmt.Errorf("maximum idle connections is negative")
+		}
+		c.MaxIdleConns = n
+		return nil
+	}
+}
+
+// WithConnMaxLifetime sets the maximum amount of time a connection may be reused.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(c *Config) error {
+		if d < 0 {
+			return fmt.Errorf("connection maximum lifetime is negative")
+		}
+		c.ConnMaxLifetime = d
+		return nil
+	}
+}
+
+// WithAcquireTimeout limits the time a read waits for a connection of the pool. If no connection
+// is available before it expires, the read fails with *sqlstore.ErrPoolExhausted.
+func WithAcquireTimeout(d time.Duration) Option {
+	return func(c *Config) error {
+		if d < 0 {
+			return fmt.Errorf("acquire timeout is negative")
+		}
+		c.AcquireTimeout = d
+		return nil
+	}
 }
 
 // New creates a new UserStore.
-func New(dbURI string, resolver sqlparser.ReadStatementResolver) (*UserStore, error) {
+// If maxRows is greater than zero, reads returning more than maxRows rows fail with *sqlstore.ErrTooManyRows.
+func New(dbURI string, resolver sqlparser.ReadStatementResolver, maxRows int, opts ...Option) (*UserStore, error) {
+	if maxRows < 0 {
+		return nil, fmt.Errorf("maximum rows count is negative")
+	}
+	config := DefaultConfig()
+	for _, o := range opts {
+		if err := o(config); err != nil {
+			return nil, fmt.Errorf("applying provided option: %s", err)
+		}
+	}
 	attrs := append([]attribute.KeyValue{attribute.String("name", "userstore")}, metrics.BaseAttrs...)
 	db, err := otelsql.Open("sqlite3", dbURI, otelsql.WithAttributes(attrs...))
 	if err != nil {
@@ -33,25 +138,214 @@ func New(dbURI string, resolver sqlparser.ReadStatementResolver) (*UserStore, er
 	if err := otelsql.RegisterDBStatsMetrics(db, otelsql.WithAttributes(attrs...)); err != nil {
 		return nil, fmt.Errorf("registering dbstats: %s", err)
 	}
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
 	return &UserStore{
 		db:       db,
 		resolver: resolver,
+		maxRows:  maxRows,
+		config:   config,
 	}, nil
 }
 
 // Read executes a read statement on the db.
+// If ctx has a deadline, the query is interrupted when it's exceeded and sqlstore.ErrReadTimeout is returned.
 func (db *UserStore) Read(ctx context.Context, rq parsing.ReadStmt) (*tableland.TableData, error) {
 	query, err := rq.GetQuery(db.resolver)
 	if err != nil {
 		return nil, fmt.Errorf("get query: %s", err)
 	}
-	ret, err := execReadQuery(ctx, db.db, query)
+	conn, err := db.acquireConn(ctx)
 	if err != nil {
+		return nil, err
+	}
+	defer db.releaseConn(conn)
+	if err := db.checkQueryCost(ctx, conn, query); err != nil {
+		return nil, err
+	}
+	ret, err := execReadQuery(ctx, conn, query, db.maxRows)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("executing read query: %w", sqlstore.ErrReadTimeout)
+		}
+		var tooManyRowsErr *sqlstore.ErrTooManyRows
+		if errors.As(err, &tooManyRowsErr) {
+			return nil, fmt.Errorf("executing read query: %w", err)
+		}
 		return nil, fmt.Errorf("parsing result to json: %s", err)
 	}
 	return ret, nil
 }
 
+// ReadStream executes a read statement on the db and writes the result to w as a JSON array of objects.
+// Rows are written as they're scanned from the db, so the result is never fully buffered in memory.
+// If the execution fails after some rows were written, w will contain an incomplete JSON array.
+func (db *UserStore) ReadStream(ctx context.Context, rq parsing.ReadStmt, w io.Writer) error {
+	query, err := rq.GetQuery(db.resolver)
+	if err != nil {
+		return fmt.Errorf("get query: %s", err)
+	}
+	conn, err := db.acquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.releaseConn(conn)
+	if err := db.checkQueryCost(ctx, conn, query); err != nil {
+		return err
+	}
+	if err := execReadQueryStream(ctx, conn, query, w, db.maxRows); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("executing read query: %w", sqlstore.ErrReadTimeout)
+		}
+		var tooManyRowsErr *sqlstore.ErrTooManyRows
+		if errors.As(err, &tooManyRowsErr) {
+			return fmt.Errorf("executing read query: %w", err)
+		}
+		return fmt.Errorf("streaming result as json: %s", err)
+	}
+	return nil
+}
+
+// ReadWithSchema executes a read statement on the db, and returns the result with the
+// name and declared type of each column.
+func (db *UserStore) ReadWithSchema(ctx context.Context, rq parsing.ReadStmt) (sqlstore.ReadResult, error) {
+	query, err := rq.GetQuery(db.resolver)
+	if err != nil {
+		return sqlstore.ReadResult{}, fmt.Errorf("get query: %s", err)
+	}
+	conn, err := db.acquireConn(ctx)
+	if err != nil {
+		return sqlstore.ReadResult{}, err
+	}
+	defer db.releaseConn(conn)
+	if err := db.checkQueryCost(ctx, conn, query); err != nil {
+		return sqlstore.ReadResult{}, err
+	}
+	ret, err := execReadQueryWithSchema(ctx, conn, query, db.maxRows)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return sqlstore.ReadResult{}, fmt.Errorf("executing read query: %w", sqlstore.ErrReadTimeout)
+		}
+		var tooManyRowsErr *sqlstore.ErrTooManyRows
+		if errors.As(err, &tooManyRowsErr) {
+			return sqlstore.ReadResult{}, fmt.Errorf("executing read query: %w", err)
+		}
+		return sqlstore.ReadResult{}, fmt.Errorf("parsing result: %s", err)
+	}
+	return ret, nil
+}
+
+// ReadScalar executes a read statement on the db that must return exactly one row with one column,
+// and returns the value of that column. e.g: SELECT count(*) FROM foo_1_1.
+func (db *UserStore) ReadScalar(ctx context.Context, rq parsing.ReadStmt) (interface{}, error) {
+	data, err := db.Read(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Columns) != 1 {
+		return nil, fmt.Errorf("scalar result must have one column but has %d", len(data.Columns))
+	}
+	if len(data.Rows) != 1 {
+		return nil, fmt.Errorf("scalar result must have one row but has %d", len(data.Rows))
+	}
+	return data.Rows[0][0].Value(), nil
+}
+
+// ExplainReadQuery returns the query plan of a read statement as reported by SQLite's EXPLAIN QUERY PLAN.
+// The plan has one step per line, indented by its depth in the plan tree.
+func (db *UserStore) ExplainReadQuery(ctx context.Context, rq parsing.ReadStmt) (string, error) {
+	query, err := rq.GetQuery(db.resolver)
+	if err != nil {
+		return "", fmt.Errorf("get query: %s", err)
+	}
+	conn, err := db.acquireConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer db.releaseConn(conn)
+	steps, err := execExplainQuery(ctx, conn, query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("explaining read query: %w", sqlstore.ErrReadTimeout)
+		}
+		return "", fmt.Errorf("explaining read query: %s", err)
+	}
+
+	var plan strings.Builder
+	for _, step := range steps {
+		plan.WriteString(strings.Repeat("  ", step.depth) + step.detail + "\n")
+	}
+	return plan.String(), nil
+}
+
+// StartRead executes a read statement on the db in the background.
+// Canceling the returned handle interrupts the query in SQLite, so it stops consuming resources right away.
+func (db *UserStore) StartRead(ctx context.Context, rq parsing.ReadStmt) *sqlstore.ReadHandle {
+	return sqlstore.NewReadHandle(ctx, func(ctx context.Context) (*tableland.TableData, error) {
+		return db.Read(ctx, rq)
+	})
+}
+
+// checkQueryCost returns *sqlstore.ErrQueryTooCostly if the estimated cost of the query
+// exceeds the configured maximum.
+func (db *UserStore) checkQueryCost(ctx context.Context, conn *sql.Conn, query string) error {
+	if db.config.MaxQueryCost == 0 {
+		return nil
+	}
+	cost, err := estimateQueryCost(ctx, conn, query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("estimating query cost: %w", sqlstore.ErrReadTimeout)
+		}
+		return fmt.Errorf("estimating query cost: %s", err)
+	}
+	if cost > db.config.MaxQueryCost {
+		return fmt.Errorf("checking query cost: %w", &sqlstore.ErrQueryTooCostly{
+			Estimate: cost,
+			Max:      db.config.MaxQueryCost,
+		})
+	}
+	return nil
+}
+
+// acquireConn gets a connection from the pool, waiting at most the configured acquire timeout.
+func (db *UserStore) acquireConn(ctx context.Context) (*sql.Conn, error) {
+	acquireCtx := ctx
+	if db.config.AcquireTimeout > 0 {
+		var cancel context.CancelFunc
+		acquireCtx, cancel = context.WithTimeout(ctx, db.config.AcquireTimeout)
+		defer cancel()
+	}
+
+	// The context is only used to wait for the connection, canceling it doesn't close the connection.
+	conn, err := db.db.Conn(acquireCtx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("acquiring connection: %w", sqlstore.ErrReadTimeout)
+		}
+		if ctx.Err() == nil && errors.Is(acquireCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("acquiring connection: %w", &sqlstore.ErrPoolExhausted{
+				Timeout: db.config.AcquireTimeout,
+			})
+		}
+		return nil, fmt.Errorf("acquiring connection: %s", err)
+	}
+	return conn, nil
+}
+
+// releaseConn returns a connection to the pool.
+func (db *UserStore) releaseConn(conn *sql.Conn) {
+	if err := conn.Close(); err != nil {
+		log.Warn().Err(err).Msg("releasing connection")
+	}
+}
+
+// PingContext verifies the connection with the db is still alive.
+func (db *UserStore) PingContext(ctx context.Context) error {
+	return db.db.PingContext(ctx)
+}
+
 // Close closes the store.
 func (db *UserStore) Close() error {
 	if err := db.db.Close(); err != nil {
@@ -60,7 +354,13 @@ func (db *UserStore) Close() error {
 	return nil
 }
 
-func execReadQuery(ctx context.Context, tx *sql.DB, q string) (*tableland.TableData, error) {
+// querier executes queries in a *sql.DB or a *sql.Conn.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func execReadQuery(ctx context.Context, tx querier, q string, maxRows int) (*tableland.TableData, error) {
 	rows, err := tx.QueryContext(ctx, q)
 	if err != nil {
 		return nil, fmt.Errorf("executing query: %s", err)
@@ -70,5 +370,119 @@ func execReadQuery(ctx context.Context, tx *sql.DB, q string) (*tableland.TableD
 			log.Warn().Err(err).Msg("closing rows")
 		}
 	}()
-	return rowsToTableData(rows)
+	return rowsToTableData(rows, maxRows)
+}
+
+func execReadQueryWithSchema(ctx context.Context, tx querier, q string, maxRows int) (sqlstore.ReadResult, error) {
+	rows, err := tx.QueryContext(ctx, q)
+	if err != nil {
+		return sqlstore.ReadResult{}, fmt.Errorf("executing query: %s", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			log.Warn().Err(err).Msg("closing rows")
+		}
+	}()
+	return rowsToReadResult(rows, maxRows)
+}
+
+func execReadQueryStream(ctx context.Context, tx querier, q string, w io.Writer, maxRows int) error {
+	rows, err := tx.QueryContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("executing query: %s", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			log.Warn().Err(err).Msg("closing rows")
+		}
+	}()
+	return rowsToJSONStream(rows, w, maxRows)
+}
+
+// planStep is a step of a query plan.
+type planStep struct {
+	depth  int
+	detail string
+}
+
+func execExplainQuery(ctx context.Context, tx querier, q string) ([]planStep, error) {
+	rows, err := tx.QueryContext(ctx, "EXPLAIN QUERY PLAN "+q)
+	if err != nil {
+		return nil, fmt.Errorf("executing query: %s", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			log.Warn().Err(err).Msg("closing rows")
+		}
+	}()
+
+	var steps []planStep
+	depths := map[int64]int{}
+	for rows.Next() {
+		var id, parent, notUsed int64
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, fmt.Errorf("scanning plan step: %s", err)
+		}
+		depth := 0
+		if parentDepth, ok := depths[parent]; ok {
+			depth = parentDepth + 1
+		}
+		depths[id] = depth
+		steps = append(steps, planStep{depth: depth, detail: detail})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating plan steps: %s", err)
+	}
+	return steps, nil
+}
+
+// scanRegEx matches a full scan step of a query plan, capturing the scanned table name.
+// e.g: "SCAN foo_1337_1", "SCAN TABLE foo_1337_1" or "SCAN foo_1337_1 USING COVERING INDEX idx".
+var scanRegEx = regexp.MustCompile(`^SCAN (?:TABLE )?(\S+)`)
+
+// estimateQueryCost estimates the cost of a read query from its plan, since SQLite doesn't report
+// cost estimates. A full scan costs the number of rows of the scanned table, and any other step
+// (e.g: an index search) has a unit cost.
+func estimateQueryCost(ctx context.Context, tx querier, q string) (int64, error) {
+	steps, err := execExplainQuery(ctx, tx, q)
+	if err != nil {
+		return 0, fmt.Errorf("explaining query: %s", err)
+	}
+
+	var cost int64
+	for _, step := range steps {
+		matches := scanRegEx.FindStringSubmatch(step.detail)
+		if matches == nil {
+			cost++
+			continue
+		}
+		rowCount, err := approxTableRowCount(ctx, tx, matches[1])
+		if err != nil {
+			return 0, fmt.Errorf("getting row count of %s: %s", matches[1], err)
+		}
+		cost += rowCount
+	}
+	return cost, nil
+}
+
+// approxTableRowCount approximates the number of rows of a table with its maximum rowid, which
+// doesn't require a full scan. Scans of things that aren't tables (e.g: a CTE or a constant row)
+// have a unit cost, since the cost of producing their rows is accounted in their own steps.
+func approxTableRowCount(ctx context.Context, tx querier, table string) (int64, error) {
+	var isTable bool
+	r := tx.QueryRowContext(ctx, "SELECT count(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?1", table)
+	if err := r.Scan(&isTable); err != nil {
+		return 0, fmt.Errorf("table lookup: %s", err)
+	}
+	if !isTable {
+		return 1, nil
+	}
+
+	var maxRowID sql.NullInt64
+	r = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT max(rowid) FROM "%s"`, table))
+	if err := r.Scan(&maxRowID); err != nil {
+		return 0, fmt.Errorf("max rowid lookup: %s", err)
+	}
+	return maxRowID.Int64, nil
 }
@@ -1,13 +1,18 @@
 package user
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
+	"github.com/tablelandnetwork/sqlparser"
+	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/sqlstore"
 	"github.com/textileio/go-tableland/tests"
 )
 
@@ -21,7 +26,7 @@ func TestReadGeneralTypeCorrectness(t *testing.T) {
 
 	// INTEGER
 	{
-		data, err := execReadQuery(ctx, db, "SELECT cast(1 as INTEGER) one")
+		data, err := execReadQuery(ctx, db, "SELECT cast(1 as INTEGER) one", 0)
 		require.NoError(t, err)
 		b, err := json.Marshal(data)
 		require.NoError(t, err)
@@ -30,7 +35,7 @@ func TestReadGeneralTypeCorrectness(t *testing.T) {
 
 	// Two INTEGERs without cast.
 	{
-		data, err := execReadQuery(ctx, db, "SELECT 1 a, 2 b")
+		data, err := execReadQuery(ctx, db, "SELECT 1 a, 2 b", 0)
 		require.NoError(t, err)
 		b, err := json.Marshal(data)
 		require.NoError(t, err)
@@ -39,7 +44,7 @@ func TestReadGeneralTypeCorrectness(t *testing.T) {
 
 	// REAL
 	{
-		data, err := execReadQuery(ctx, db, "SELECT cast(1.2 as REAL) real")
+		data, err := execReadQuery(ctx, db, "SELECT cast(1.2 as REAL) real", 0)
 		require.NoError(t, err)
 		b, err := json.Marshal(data)
 		require.NoError(t, err)
@@ -48,7 +53,7 @@ func TestReadGeneralTypeCorrectness(t *testing.T) {
 
 	// TEXT
 	{
-		data, err := execReadQuery(ctx, db, "SELECT 'hello' text")
+		data, err := execReadQuery(ctx, db, "SELECT 'hello' text", 0)
 		require.NoError(t, err)
 		b, err := json.Marshal(data)
 		require.NoError(t, err)
@@ -57,10 +62,375 @@ func TestReadGeneralTypeCorrectness(t *testing.T) {
 
 	// BLOB
 	{
-		data, err := execReadQuery(ctx, db, "SELECT cast(X'4141414141414141414141' as BLOB) blob")
+		data, err := execReadQuery(ctx, db, "SELECT cast(X'4141414141414141414141' as BLOB) blob", 0)
 		require.NoError(t, err)
 		b, err := json.Marshal(data)
 		require.NoError(t, err)
 		require.JSONEq(t, `{"columns":[{"name":"blob"}],"rows":[["QUFBQUFBQUFBQUE="]]}`, string(b))
 	}
+	// NULL
+	{
+		data, err := execReadQuery(ctx, db, "SELECT NULL empty", 0)
+		require.NoError(t, err)
+		b, err := json.Marshal(data)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"columns":[{"name":"empty"}],"rows":[[null]]}`, string(b))
+	}
+	// Mixed row. SQLite doesn't have a boolean storage class, so booleans are integers.
+	{
+		data, err := execReadQuery(ctx, db, "SELECT 42 i, true b, 4.5 f, NULL n", 0)
+		require.NoError(t, err)
+		b, err := json.Marshal(data)
+		require.NoError(t, err)
+		require.JSONEq(t,
+			`{"columns":[{"name":"i"},{"name":"b"},{"name":"f"},{"name":"n"}],"rows":[[42, 1, 4.5, null]]}`,
+			string(b))
+	}
+}
+
+func TestReadStream(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite3", tests.Sqlite3URI(t))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("shape", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		q := "SELECT 1 b, 'one' a, NULL c, json_object('k', 1) d UNION ALL SELECT 2, 'two', NULL, '[1,2]'"
+		require.NoError(t, execReadQueryStream(ctx, db, q, &buf, 0))
+		require.Equal(t,
+			`[{"b":1,"a":"one","c":null,"d":{"k":1}},{"b":2,"a":"two","c":null,"d":[1,2]}]`,
+			buf.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, execReadQueryStream(ctx, db, "SELECT 1 a WHERE 1=0", &buf, 0))
+		require.Equal(t, "[]", buf.String())
+	})
+
+	t.Run("large", func(t *testing.T) {
+		t.Parallel()
+
+		q := `WITH RECURSIVE cnt(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM cnt WHERE x < 100000)
+			  SELECT x, 'some text value' txt FROM cnt`
+		w := &countingWriter{}
+		require.NoError(t, execReadQueryStream(ctx, db, q, w, 0))
+
+		// The writer only ever receives chunks of a buffered size, never the whole result.
+		require.Greater(t, w.count, 100000*len(`{"x":1,"txt":"some text value"}`))
+		require.LessOrEqual(t, w.maxWrite, 4096)
+		require.Greater(t, w.flushes, 100000/streamFlushRows-1)
+	})
+}
+
+type countingWriter struct {
+	count    int
+	maxWrite int
+	flushes  int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.count += len(p)
+	if len(p) > w.maxWrite {
+		w.maxWrite = len(p)
+	}
+	return len(p), nil
+}
+
+func (w *countingWriter) Flush() {
+	w.flushes++
+}
+
+func TestReadWithSchema(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, "CREATE TABLE foo (id INTEGER PRIMARY KEY, name TEXT, data BLOB)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO foo VALUES (1, 'bar', NULL)")
+	require.NoError(t, err)
+
+	store, err := New(dbURI, nil, 0)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	res, err := store.ReadWithSchema(ctx, &rawReadStmt{query: "SELECT id, name, data, count(*) c FROM foo"})
+	require.NoError(t, err)
+	require.Equal(t, []sqlstore.ColumnDescriptor{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "text"},
+		{Name: "data", Type: "blob"},
+		{Name: "c", Type: ""},
+	}, res.Columns)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, int64(1), res.Rows[0][0].Value())
+	require.Equal(t, "bar", res.Rows[0][1].Value())
+	require.Nil(t, res.Rows[0][2].Value())
+	require.Equal(t, int64(1), res.Rows[0][3].Value())
+}
+
+func TestReadTimeout(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(tests.Sqlite3URI(t), nil, 0)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	ctx, cls := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cls()
+
+	slowQuery := `WITH RECURSIVE cnt(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM cnt) SELECT count(*) FROM cnt`
+	_, err = store.Read(ctx, &rawReadStmt{query: slowQuery})
+	require.ErrorIs(t, err, sqlstore.ErrReadTimeout)
+}
+
+func TestStartReadCancel(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(tests.Sqlite3URI(t), nil, 0)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+	ctx := context.Background()
+
+	h := store.StartRead(ctx, &rawReadStmt{query: "SELECT 1"})
+	data, err := h.Wait()
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 1)
+	h.Cancel()
+
+	slowQuery := `WITH RECURSIVE cnt(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM cnt) SELECT count(*) FROM cnt`
+	h = store.StartRead(ctx, &rawReadStmt{query: slowQuery})
+	select {
+	case <-h.Done():
+		t.Fatal("slow query completed before being canceled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	start := time.Now()
+	h.Cancel()
+	_, err = h.Wait()
+	require.ErrorIs(t, err, sqlstore.ErrReadCanceled)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestReadScalar(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(tests.Sqlite3URI(t), nil, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	ctx := context.Background()
+
+	t.Run("scalar", func(t *testing.T) {
+		t.Parallel()
+
+		q := "WITH t(x) AS (VALUES (1), (2), (3)) SELECT count(*) FROM t"
+		value, err := store.ReadScalar(ctx, &rawReadStmt{query: q})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), value)
+	})
+
+	t.Run("multiple columns", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := store.ReadScalar(ctx, &rawReadStmt{query: "SELECT 1 a, 2 b"})
+		require.Error(t, err)
+	})
+
+	t.Run("multiple rows", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := store.ReadScalar(ctx, &rawReadStmt{query: "WITH t(x) AS (VALUES (1), (2)) SELECT x FROM t"})
+		require.Error(t, err)
+	})
+}
+
+func TestReadMaxRows(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE foo_1337_1 (a INT);
+		WITH RECURSIVE cnt(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM cnt WHERE x < 100)
+		INSERT INTO foo_1337_1 SELECT x FROM cnt`)
+	require.NoError(t, err)
+
+	store, err := New(dbURI, nil, 10)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	ctx := context.Background()
+
+	// The query doesn't have a LIMIT clause, so the store cap is the last line of defense.
+	_, err = store.Read(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1"})
+	var tooManyRowsErr *sqlstore.ErrTooManyRows
+	require.ErrorAs(t, err, &tooManyRowsErr)
+	require.Equal(t, 10, tooManyRowsErr.Max)
+
+	err = store.ReadStream(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1"}, &bytes.Buffer{})
+	require.ErrorAs(t, err, &tooManyRowsErr)
+
+	data, err := store.Read(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1 LIMIT 10"})
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 10)
+}
+
+func TestPoolOptions(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(
+		tests.Sqlite3URI(t),
+		nil,
+		0,
+		WithMaxOpenConns(3),
+		WithMaxIdleConns(1),
+		WithConnMaxLifetime(time.Minute),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+
+	require.Equal(t, 3, store.db.Stats().MaxOpenConnections)
+
+	_, err = New(tests.Sqlite3URI(t), nil, 0, WithMaxOpenConns(-1))
+	require.Error(t, err)
+}
+
+func TestPoolExhausted(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(tests.Sqlite3URI(t), nil, 0, WithMaxOpenConns(1), WithAcquireTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	ctx := context.Background()
+
+	// Hold the only connection of the pool.
+	conn, err := store.db.Conn(ctx)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = store.Read(ctx, &rawReadStmt{query: "SELECT 1"})
+	var poolExhaustedErr *sqlstore.ErrPoolExhausted
+	require.ErrorAs(t, err, &poolExhaustedErr)
+	require.Equal(t, 50*time.Millisecond, poolExhaustedErr.Timeout)
+	require.Less(t, time.Since(start), time.Second)
+
+	err = store.ReadStream(ctx, &rawReadStmt{query: "SELECT 1"}, &bytes.Buffer{})
+	require.ErrorAs(t, err, &poolExhaustedErr)
+
+	require.NoError(t, conn.Close())
+	_, err = store.Read(ctx, &rawReadStmt{query: "SELECT 1"})
+	require.NoError(t, err)
+}
+
+func TestReadMaxQueryCost(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE foo_1337_1 (a INT);
+		CREATE INDEX foo_1337_1_a ON foo_1337_1 (a);
+		WITH RECURSIVE cnt(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM cnt WHERE x < 1000)
+		INSERT INTO foo_1337_1 SELECT x FROM cnt`)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("low threshold", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := New(dbURI, nil, 0, WithMaxQueryCost(100))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, store.Close()) })
+
+		// A full scan is rejected.
+		_, err = store.Read(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1 WHERE a + 1 > 10"})
+		var tooCostlyErr *sqlstore.ErrQueryTooCostly
+		require.ErrorAs(t, err, &tooCostlyErr)
+		require.Equal(t, int64(1000), tooCostlyErr.Estimate)
+		require.Equal(t, int64(100), tooCostlyErr.Max)
+
+		err = store.ReadStream(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1"}, &bytes.Buffer{})
+		require.ErrorAs(t, err, &tooCostlyErr)
+
+		// An index search is accepted.
+		data, err := store.Read(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1 WHERE a = 10"})
+		require.NoError(t, err)
+		require.Len(t, data.Rows, 1)
+	})
+
+	t.Run("high threshold", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := New(dbURI, nil, 0, WithMaxQueryCost(10000))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, store.Close()) })
+
+		data, err := store.Read(ctx, &rawReadStmt{query: "SELECT * FROM foo_1337_1 WHERE a + 1 > 10"})
+		require.NoError(t, err)
+		require.Len(t, data.Rows, 991)
+	})
+}
+
+func TestExplainReadQuery(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE foo_1337_1 (a INT, b TEXT)")
+	require.NoError(t, err)
+
+	store, err := New(dbURI, nil, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	ctx := context.Background()
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	t.Run("valid query", func(t *testing.T) {
+		t.Parallel()
+
+		rq, err := parser.ValidateReadQuery("SELECT a FROM foo_1337_1 WHERE b = 'bar' ORDER BY a")
+		require.NoError(t, err)
+		plan, err := store.ExplainReadQuery(ctx, rq)
+		require.NoError(t, err)
+		require.Contains(t, plan, "SCAN")
+	})
+
+	t.Run("rejected queries", func(t *testing.T) {
+		t.Parallel()
+
+		// Queries are validated before reaching the store, so these never get explained.
+		_, err := parser.ValidateReadQuery("SELECT * FROM foo_1337_1 FOR UPDATE")
+		require.Error(t, err)
+		_, err = parser.ValidateReadQuery("DELETE FROM foo_1337_1")
+		require.Error(t, err)
+	})
+}
+
+type rawReadStmt struct {
+	query string
+}
+
+func (s *rawReadStmt) GetQuery(_ sqlparser.ReadStatementResolver) (string, error) {
+	return s.query, nil
+}
+
+func (s *rawReadStmt) GetReferencedTables() []string {
+	return nil
+}
+
+func (s *rawReadStmt) IsOrdered() bool {
+	return false
 }
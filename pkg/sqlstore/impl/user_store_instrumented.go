@@ -3,6 +3,7 @@ package impl
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/textileio/go-tableland/internal/tableland"
@@ -57,6 +58,82 @@ func (s *InstrumentedUserStore) Read(ctx context.Context, stmt parsing.ReadStmt)
 	return data, err
 }
 
+// ReadStream executes a read statement on the db streaming the result to w.
+func (s *InstrumentedUserStore) ReadStream(ctx context.Context, stmt parsing.ReadStmt, w io.Writer) error {
+	start := time.Now()
+	err := s.store.ReadStream(ctx, stmt, w)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ReadStream")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return err
+}
+
+// ReadWithSchema executes a read statement on the db returning the result with its column types.
+func (s *InstrumentedUserStore) ReadWithSchema(ctx context.Context, stmt parsing.ReadStmt) (sqlstore.ReadResult, error) {
+	start := time.Now()
+	res, err := s.store.ReadWithSchema(ctx, stmt)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ReadWithSchema")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return res, err
+}
+
+// ReadScalar executes a read statement on the db that must return a single value.
+func (s *InstrumentedUserStore) ReadScalar(ctx context.Context, stmt parsing.ReadStmt) (interface{}, error) {
+	start := time.Now()
+	value, err := s.store.ReadScalar(ctx, stmt)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ReadScalar")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return value, err
+}
+
+// ExplainReadQuery returns the query plan of a read statement.
+func (s *InstrumentedUserStore) ExplainReadQuery(ctx context.Context, stmt parsing.ReadStmt) (string, error) {
+	start := time.Now()
+	plan, err := s.store.ExplainReadQuery(ctx, stmt)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ExplainReadQuery")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	s.callCount.Add(ctx, 1, attributes...)
+	s.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return plan, err
+}
+
+// StartRead executes a read statement on the db in the background.
+// The read is recorded in the metrics of Read.
+func (s *InstrumentedUserStore) StartRead(ctx context.Context, stmt parsing.ReadStmt) *sqlstore.ReadHandle {
+	return sqlstore.NewReadHandle(ctx, func(ctx context.Context) (*tableland.TableData, error) {
+		return s.Read(ctx, stmt)
+	})
+}
+
 // Close closes the store.
 func (s *InstrumentedUserStore) Close() error {
 	return s.store.Close()
@@ -17,6 +17,10 @@ type SystemStore interface {
 	GetTablesByController(context.Context, string) ([]Table, error)
 
 	GetACLOnTableByController(context.Context, tables.TableID, string) (SystemACL, error)
+	GetCreatePrivilegesByController(context.Context, string) (tableland.Privileges, error)
+	GrantCreatePrivilege(context.Context, common.Address) error
+
+	GetAuditLog(context.Context, tables.TableID, int64, int) ([]AuditEntry, error)
 
 	ListPendingTx(context.Context, common.Address) ([]nonce.PendingTx, error)
 	InsertPendingTx(context.Context, common.Address, int64, common.Hash) error
@@ -24,6 +28,7 @@ type SystemStore interface {
 	ReplacePendingTxByHash(context.Context, common.Hash, common.Hash) error
 
 	GetReceipt(context.Context, string) (eventprocessor.Receipt, bool, error)
+	GetReceipts(context.Context, []string) ([]eventprocessor.Receipt, error)
 
 	GetTablesByStructure(context.Context, string) ([]Table, error)
 	GetSchemaByTableName(context.Context, string) (TableSchema, error)
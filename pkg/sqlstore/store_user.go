@@ -2,13 +2,122 @@ package sqlstore
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
 
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/pkg/parsing"
 )
 
+// ErrReadTimeout is returned when a read query is canceled because the context deadline was exceeded.
+var ErrReadTimeout = errors.New("read query timed out")
+
+// ErrReadCanceled is returned when a read query is stopped by the Cancel method of its ReadHandle.
+var ErrReadCanceled = errors.New("read query canceled")
+
+// ErrTooManyRows is returned when a read query returns more rows than the maximum allowed.
+type ErrTooManyRows struct {
+	Max int
+}
+
+func (e *ErrTooManyRows) Error() string {
+	return fmt.Sprintf("query result has more than %d rows", e.Max)
+}
+
+// ErrQueryTooCostly is returned when the estimated cost of a read query exceeds the maximum allowed.
+type ErrQueryTooCostly struct {
+	Estimate int64
+	Max      int64
+}
+
+func (e *ErrQueryTooCostly) Error() string {
+	return fmt.Sprintf("query estimated cost is too high (has %d, max %d)", e.Estimate, e.Max)
+}
+
+// ErrPoolExhausted is returned when a read query can't get a db connection before the acquire timeout
+// expires, because all of them are in use. Callers should back off before retrying.
+type ErrPoolExhausted struct {
+	Timeout time.Duration
+}
+
+func (e *ErrPoolExhausted) Error() string {
+	return fmt.Sprintf("no db connection available after %s", e.Timeout)
+}
+
+// ColumnDescriptor describes a column of a read query result.
+type ColumnDescriptor struct {
+	Name string `json:"name"`
+	// Type is the declared type of the table column the result column comes from, in lowercase.
+	// It's empty if the result column is an expression (e.g: count(*)).
+	Type string `json:"type"`
+}
+
+// ReadResult is the result of a read query together with the description of its columns.
+type ReadResult struct {
+	Columns []ColumnDescriptor         `json:"columns"`
+	Rows    [][]*tableland.ColumnValue `json:"rows"`
+}
+
 // UserStore defines the methods for interacting with user data.
 type UserStore interface {
 	Read(context.Context, parsing.ReadStmt) (*tableland.TableData, error)
+	ReadStream(context.Context, parsing.ReadStmt, io.Writer) error
+	ReadWithSchema(context.Context, parsing.ReadStmt) (ReadResult, error)
+	ReadScalar(context.Context, parsing.ReadStmt) (interface{}, error)
+	ExplainReadQuery(context.Context, parsing.ReadStmt) (string, error)
+	StartRead(context.Context, parsing.ReadStmt) *ReadHandle
 	Close() error
 }
+
+// ReadHandle is a read query running in the background that can be canceled before it completes.
+type ReadHandle struct {
+	cancel   context.CancelFunc
+	done     chan struct{}
+	canceled int32
+
+	data *tableland.TableData
+	err  error
+}
+
+// NewReadHandle starts read in a new goroutine and returns a handle to it.
+// The context passed to read is canceled when the handle's Cancel method is called.
+func NewReadHandle(
+	ctx context.Context,
+	read func(context.Context) (*tableland.TableData, error),
+) *ReadHandle {
+	ctx, cancel := context.WithCancel(ctx)
+	h := &ReadHandle{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(h.done)
+		defer cancel()
+		h.data, h.err = read(ctx)
+		if h.err != nil && atomic.LoadInt32(&h.canceled) == 1 {
+			h.err = fmt.Errorf("executing read query: %w", ErrReadCanceled)
+		}
+	}()
+	return h
+}
+
+// Cancel stops the read query. It's safe to call it more than once, or after the query completed.
+func (h *ReadHandle) Cancel() {
+	atomic.StoreInt32(&h.canceled, 1)
+	h.cancel()
+}
+
+// Done returns a channel that's closed when the read query completes.
+func (h *ReadHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait blocks until the read query completes and returns its result.
+// If the query was stopped by Cancel, the error wraps ErrReadCanceled.
+func (h *ReadHandle) Wait() (*tableland.TableData, error) {
+	<-h.done
+	return h.data, h.err
+}
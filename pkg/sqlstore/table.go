@@ -1,26 +1,27 @@
 package sqlstore
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/parsing"
 	"github.com/textileio/go-tableland/pkg/tables"
 )
 
 // Table represents a system-wide table stored in Tableland.
 type Table struct {
-	ID         tables.TableID    `json:"id"` // table id
-	ChainID    tableland.ChainID `json:"chain_id"`
-	Controller string            `json:"controller"` // controller address
-	Prefix     string            `json:"prefix"`
-	Structure  string            `json:"structure"`
-	CreatedAt  time.Time         `json:"created_at"`
+	ID          tables.TableID    `json:"id"` // table id
+	ChainID     tableland.ChainID `json:"chain_id"`
+	Controller  string            `json:"controller"` // controller address
+	Prefix      string            `json:"prefix"`
+	Structure   string            `json:"structure"`
+	Description string            `json:"description"`
+	CreatedAt   time.Time         `json:"created_at"`
 }
 
 // Name returns table's full name.
 func (t Table) Name() string {
-	return fmt.Sprintf("%s_%d_%s", t.Prefix, t.ChainID, t.ID)
+	return parsing.PhysicalTableName(t.Prefix, t.ChainID, t.ID)
 }
 
 // TableSchema represents the schema of a table.
@@ -33,6 +34,7 @@ type TableSchema struct {
 type ColumnSchema struct {
 	Name        string
 	Type        string
+	NotNull     bool
 	Constraints []string
 }
 
@@ -64,6 +66,19 @@ type SystemACL struct {
 	UpdatedAt  *time.Time
 }
 
+// AuditEntry represents an entry of the audit log of executed write statements.
+type AuditEntry struct {
+	ID           int64
+	ChainID      tableland.ChainID
+	TableID      tables.TableID
+	Controller   string
+	Operation    string
+	RowsAffected int64
+	BlockNumber  int64
+	TxnHash      string
+	CreatedAt    time.Time
+}
+
 // Receipt represents a Tableland receipt.
 type Receipt struct {
 	ChainID      tableland.ChainID
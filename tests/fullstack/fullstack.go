@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/textileio/go-tableland/internal/chains"
 	"github.com/textileio/go-tableland/internal/router"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/system"
 	systemimpl "github.com/textileio/go-tableland/internal/system/impl"
 	"github.com/textileio/go-tableland/internal/tableland"
@@ -141,17 +142,20 @@ func CreateFullStack(t *testing.T, deps Deps) FullStack {
 		},
 	}
 
-	tbl := deps.Tableland
+	tbl, tblImpl := deps.Tableland, "mock"
 	if tbl == nil {
+		tblImpl = "mesa"
 		userStore := deps.UserStore
 		if userStore == nil {
 			userStore, err = user.New(
 				dbURI,
 				rsresolver.New(map[tableland.ChainID]eventprocessor.EventProcessor{1337: ep}),
+				0,
 			)
 			require.NoError(t, err)
 		}
-		tbl = impl.NewTablelandMesa(parser, userStore, chainStacks)
+		tbl, err = impl.NewTablelandMesa(parser, userStore, chainStacks)
+		require.NoError(t, err)
 		tbl, err = impl.NewInstrumentedTablelandMesa(tbl)
 		require.NoError(t, err)
 	}
@@ -174,7 +178,23 @@ func CreateFullStack(t *testing.T, deps Deps) FullStack {
 		require.NoError(t, err)
 	}
 
-	router, err := router.ConfiguredRouter(tbl, systemService, 10, time.Second, []tableland.ChainID{ChainID})
+	readiness, err := sqlstoreimplsystem.NewReadinessChecker(dbURI)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = readiness.Close() })
+
+	router, err := router.ConfiguredRouter(
+		tbl,
+		tblImpl,
+		systemService,
+		db,
+		readiness,
+		10,
+		time.Second,
+		nil,
+		[]tableland.ChainID{ChainID},
+		nil,
+		middlewares.CORSConfig{AllowedOrigins: []string{"*"}},
+	)
 	require.NoError(t, err)
 
 	server := httptest.NewServer(router.Handler())
@@ -10,7 +10,7 @@ import (
 )
 
 // Sqlite3URI returns a URI to spinup an in-memory Sqlite database.
-func Sqlite3URI(t *testing.T) string {
+func Sqlite3URI(t testing.TB) string {
 	dbURI := "file::" + uuid.NewString() + ":?mode=memory&cache=shared&_foreign_keys=on"
 	db, err := sql.Open("sqlite3", dbURI)
 	require.NoError(t, err)